@@ -0,0 +1,456 @@
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/internal/danger"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// capturedTable holds the content of a table decoded by an
+// unstable.Unmarshaler, as the text of its key-values and sub-tables.
+type capturedTable struct {
+	keyValues []capturedSource
+	children  []*capturedChild
+}
+
+// capturedSource is a part of the document copied into a captured table.
+type capturedSource struct {
+	text string
+	raw  unstable.Range
+}
+
+// capturedChild is a table or an array table defined by a header inside a
+// captured table.
+type capturedChild struct {
+	name string         // Unquoted key.
+	key  capturedSource // Key as written in the document.
+
+	table    *capturedTable   // Nil for array tables.
+	elements []*capturedTable // Elements of array tables.
+}
+
+// child returns the child of t with the given name, creating it if needed.
+func (t *capturedTable) child(name string, key capturedSource) *capturedChild {
+	for _, c := range t.children {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &capturedChild{name: name, key: key}
+	t.children = append(t.children, c)
+	return c
+}
+
+// open returns the table in t designated by the header key parts, appending
+// an element when kind is an array table.
+func (t *capturedTable) open(kind unstable.Kind, names []string, keys []capturedSource) *capturedTable {
+	for i := range names {
+		c := t.child(names[i], keys[i])
+		if i == len(names)-1 && kind == unstable.ArrayTable {
+			e := &capturedTable{}
+			c.elements = append(c.elements, e)
+			return e
+		}
+		if len(c.elements) > 0 {
+			t = c.elements[len(c.elements)-1]
+			continue
+		}
+		if c.table == nil {
+			c.table = &capturedTable{}
+		}
+		t = c.table
+	}
+	return t
+}
+
+// capturedText is the text of a captured table, written as an inline table.
+type capturedText struct {
+	b []byte
+
+	// Location in the document of the key-values written in b.
+	segments []capturedSegment
+}
+
+type capturedSegment struct {
+	offset int // In b.
+	raw    unstable.Range
+}
+
+// writeTo writes t to c as an inline table. Sub-tables are written as dotted
+// keys, because they can extend tables defined with dotted keys.
+func (t *capturedTable) writeTo(c *capturedText) {
+	c.b = append(c.b, '{')
+	t.writeKeyValues(c, nil)
+	c.b = append(c.b, '}')
+}
+
+func (t *capturedTable) writeKeyValues(c *capturedText, path []capturedSource) {
+	for _, kv := range t.keyValues {
+		c.separate()
+		c.writeKey(path)
+		c.write(kv)
+	}
+
+	for _, ch := range t.children {
+		p := append(path[:len(path):len(path)], ch.key)
+
+		if ch.table == nil {
+			c.separate()
+			c.writeKey(p[:len(p)-1])
+			c.write(ch.key)
+			c.b = append(c.b, " = ["...)
+			for i, e := range ch.elements {
+				if i > 0 {
+					c.b = append(c.b, ", "...)
+				}
+				e.writeTo(c)
+			}
+			c.b = append(c.b, ']')
+			continue
+		}
+
+		n := len(c.b)
+		ch.table.writeKeyValues(c, p)
+		if len(c.b) == n {
+			// Empty tables are still defined.
+			c.separate()
+			c.writeKey(p[:len(p)-1])
+			c.write(ch.key)
+			c.b = append(c.b, " = {}"...)
+		}
+	}
+}
+
+// write writes a part of the document to c.
+func (c *capturedText) write(s capturedSource) {
+	c.segments = append(c.segments, capturedSegment{offset: len(c.b), raw: s.raw})
+	c.b = append(c.b, s.text...)
+}
+
+// writeKey writes the dotted key prefix made of path.
+func (c *capturedText) writeKey(path []capturedSource) {
+	for _, k := range path {
+		c.write(k)
+		c.b = append(c.b, '.')
+	}
+}
+
+// separate writes the separator preceding a key-value, unless it is the first
+// one of its table.
+func (c *capturedText) separate() {
+	if c.b[len(c.b)-1] != '{' {
+		c.b = append(c.b, ", "...)
+	}
+}
+
+// document returns the range of the document corresponding to highlight, a
+// part of c. Highlights spanning several parts of the document are truncated
+// to the first one.
+func (c *capturedText) document(highlight []byte) (unstable.Range, bool) {
+	if !danger.IsSubslice(c.b, highlight) {
+		return unstable.Range{}, false
+	}
+	offset := danger.SubsliceOffset(c.b, highlight)
+	for i := len(c.segments) - 1; i >= 0; i-- {
+		s := c.segments[i]
+		end := s.offset + int(s.raw.Length)
+		if offset < s.offset || offset >= end {
+			continue
+		}
+		length := len(highlight)
+		if offset+length > end {
+			length = end - offset
+		}
+		return unstable.Range{
+			Offset: s.raw.Offset + uint32(offset-s.offset),
+			Length: uint32(length),
+		}, true
+	}
+	return unstable.Range{}, false
+}
+
+// tableUnmarshaler returns the unstable.Unmarshaler decoding the table stored
+// in v, if any.
+func (d *decoder) tableUnmarshaler(v reflect.Value) (unstable.Unmarshaler, bool) {
+	if !d.unmarshalerInterface {
+		return nil, false
+	}
+
+	t := v.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil, false
+	}
+
+	for v.Kind() == reflect.Ptr {
+		v = initAndDereferencePointer(v)
+	}
+	if !v.CanAddr() || !v.Addr().CanInterface() {
+		return nil, false
+	}
+
+	return v.Addr().Interface().(unstable.Unmarshaler), true
+}
+
+// tryCapturedTable decodes the table designated by the parts of key consumed
+// so far as a whole, when v implements unstable.Unmarshaler or is an interface
+// with registered variants. The table is made of the key-values and sub-tables
+// following the current header, until the next header that is not part of the
+// table.
+func (d *decoder) tryCapturedTable(key unstable.Iterator, v reflect.Value) (bool, error) {
+	u, ok := d.tableUnmarshaler(v)
+	vs := d.tableVariants(v)
+	if !ok && vs == nil {
+		return false, nil
+	}
+
+	header := d.expr()
+	highlight := header.Extent()
+
+	text := capturedText{b: []byte("v = ")}
+	errs := d.capturedErrors(&text, highlight)
+
+	// The parts of the header key after the current one designate a
+	// sub-table of the captured table.
+	var prefix, names []string
+	var keys []capturedSource
+	captured := true
+	it := header.Key()
+	for it.Next() {
+		n := it.Node()
+		if captured {
+			prefix = append(prefix, string(n.Data))
+		} else {
+			names = append(names, string(n.Data))
+			keys = append(keys, d.capturedSource(n.Raw))
+		}
+		if n == key.Node() {
+			captured = false
+		}
+	}
+
+	root := &capturedTable{}
+	table := root.open(header.Kind, names, keys)
+
+	for d.nextExpr() {
+		expr := d.expr()
+		if expr.Kind != unstable.KeyValue {
+			names, keys, ok = d.capturedHeaderKey(expr, prefix)
+			if !ok {
+				d.stashExpr()
+				break
+			}
+		}
+
+		_, err := d.seen.CheckExpression(expr)
+		if err != nil {
+			return true, d.duplicateKeyError(expr, err)
+		}
+		d.fieldTags.Record(expr)
+
+		switch expr.Kind {
+		case unstable.KeyValue:
+			d.strict.EnterKeyValue(expr)
+			d.strict.ExitKeyValue(expr)
+			table.keyValues = append(table.keyValues, d.capturedKeyValue(expr))
+		case unstable.Table:
+			d.strict.EnterTable(expr)
+			table = root.open(expr.Kind, names, keys)
+		case unstable.ArrayTable:
+			d.strict.EnterArrayTable(expr)
+			table = root.open(expr.Kind, names, keys)
+		}
+	}
+
+	root.writeTo(&text)
+	p := unstable.Parser{SpecVersion: d.p.SpecVersion}
+	p.Reset(text.b)
+
+	var err error
+	if !p.NextExpression() {
+		err = fmt.Errorf("toml: cannot build table %s: %w", strings.Join(prefix, "."), p.Error())
+	} else if u != nil {
+		err = u.UnmarshalTOML(p.Expression().Value())
+	} else {
+		// The keys of the table are recorded in the metadata by d. The
+		// missing fields are found by dd and located in the document.
+		dd := d.nested(&p)
+		dd.strict = strict{Enabled: d.strict.tracking() && d.stream == nil}
+		dd.fieldTags = fieldTags{}
+		dd.tableKey.SetRoot(prefix)
+		dd.captured = errs
+		err = dd.unmarshalVariant(vs, p.Expression().Value(), v)
+		d.captureMissingFields(&text, prefix, highlight, dd.strict.missing)
+		d.errors = append(d.errors, dd.errors...)
+		d.fieldTags.missing = append(d.fieldTags.missing, dd.fieldTags.missing...)
+	}
+	if err == nil {
+		return true, nil
+	}
+
+	// Errors of an Unmarshaler do not point at the text of the table.
+	var perr *unstable.ParserError
+	if u == nil {
+		errors.As(err, &perr)
+	}
+	derr := errs.wrapError(err, perr)
+	if d.collectErrors {
+		d.errors = append(d.errors, *derr)
+		return true, nil
+	}
+	return true, derr
+}
+
+// capturedErrors reports the errors of a decoder reading the text of a
+// captured table in the document the table comes from.
+type capturedErrors struct {
+	// Decoder of the document.
+	d    *decoder
+	text *capturedText
+
+	// Part of the document containing the header of the table, starting at
+	// the given line and offset.
+	doc          []byte
+	line, offset int
+	header       []byte
+
+	// Index in the metadata of the header of the table, if recorded.
+	table    int
+	hasTable bool
+}
+
+// capturedErrors returns the capturedErrors of the table whose header is
+// located at highlight, and whose text is written to text. When streaming,
+// the part of the document containing the header is discarded while the
+// table is read, so it is copied.
+func (d *decoder) capturedErrors(text *capturedText, highlight unstable.Range) *capturedErrors {
+	doc, line, offset := d.document()
+	header := d.p.Raw(highlight)
+	if d.stream != nil {
+		start := danger.SubsliceOffset(doc, header)
+		doc = append([]byte(nil), doc...)
+		header = doc[start : start+len(header)]
+	}
+
+	return &capturedErrors{
+		d:        d,
+		text:     text,
+		doc:      doc,
+		line:     line,
+		offset:   offset,
+		header:   header,
+		table:    d.strict.table,
+		hasTable: d.strict.hasTable,
+	}
+}
+
+// wrapError contextualizes err. perr, if not nil, points at the text of the
+// table, and is mapped back to the document while it is still available.
+// Otherwise, the header of the table is reported. The key of the error is
+// marked as not decoded in the metadata.
+func (c *capturedErrors) wrapError(err error, perr *unstable.ParserError) *DecodeError {
+	highlight := c.header
+	var key Key
+	marked := false
+	if perr != nil {
+		key = perr.Key
+		if r, ok := c.text.document(perr.Highlight); ok && c.d.stream == nil {
+			highlight = c.d.p.Raw(r)
+			if md := c.d.strict.Metadata; md != nil {
+				marked = md.undecoded(key, int(r.Offset))
+			}
+		}
+	}
+	if md := c.d.strict.Metadata; md != nil && !marked && c.hasTable {
+		md.keys[c.table].Decoded = false
+	}
+
+	return c.d.wrapErrorAt(c.doc, c.line, c.offset, err, &unstable.ParserError{
+		Highlight: highlight,
+		Message:   strings.TrimPrefix(err.Error(), "toml: "),
+		Key:       key,
+	})
+}
+
+// captureMissingFields adds the missing fields found while decoding the text
+// of the table prefix to the ones of the document, and marks them as undecoded
+// in the metadata. Fields that cannot be located in the document are reported
+// at the header of the table.
+func (d *decoder) captureMissingFields(text *capturedText, prefix []string, header unstable.Range, missing []missingField) {
+	for _, m := range missing {
+		key := make([]string, 0, len(prefix)+len(m.Key))
+		key = append(key, prefix...)
+		key = append(key, m.Key...)
+
+		r, ok := text.document(m.Highlight)
+		if ok && d.strict.Metadata != nil {
+			d.strict.Metadata.undecoded(key, int(r.Offset))
+		}
+		if !d.strict.Enabled {
+			continue
+		}
+		if !ok {
+			r = header
+		}
+		d.strict.missing = append(d.strict.missing, missingField{
+			ParserError: unstable.ParserError{
+				Highlight: d.p.Raw(r),
+				Message:   m.Message,
+				Key:       key,
+			},
+			found: m.found,
+		})
+	}
+}
+
+// capturedHeaderKey returns the parts of the key of the header expr relative
+// to the captured table prefix. ok is false when the header is not part of the
+// captured table.
+func (d *decoder) capturedHeaderKey(expr *unstable.Node, prefix []string) (names []string, keys []capturedSource, ok bool) {
+	i := 0
+	it := expr.Key()
+	for it.Next() {
+		n := it.Node()
+		if i < len(prefix) {
+			if string(n.Data) != prefix[i] {
+				return nil, nil, false
+			}
+		} else {
+			names = append(names, string(n.Data))
+			keys = append(keys, d.capturedSource(n.Raw))
+		}
+		i++
+	}
+
+	// A new element of the array table ends the capture of the previous one.
+	if i < len(prefix) || (len(names) == 0 && expr.Kind == unstable.ArrayTable) {
+		return nil, nil, false
+	}
+
+	return names, keys, true
+}
+
+// capturedSource copies the given range of the document.
+func (d *decoder) capturedSource(raw unstable.Range) capturedSource {
+	return capturedSource{text: string(d.p.Raw(raw)), raw: raw}
+}
+
+// capturedKeyValue returns the key-value expr as written in the document,
+// without its comment.
+func (d *decoder) capturedKeyValue(expr *unstable.Node) capturedSource {
+	it := expr.Key()
+	it.Next()
+	start := it.Node().Raw.Offset
+	value := expr.Value().Extent()
+
+	return d.capturedSource(unstable.Range{
+		Offset: start,
+		Length: value.Offset + value.Length - start,
+	})
+}
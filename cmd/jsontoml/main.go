@@ -10,6 +10,11 @@
 //
 //	jsontoml file.json > file.toml
 //
+// Keeping the keys of objects in the order of the document instead of sorting
+// them:
+//
+//	jsontoml -keep-order file.json > file.toml
+//
 // # Installation
 //
 // Using Go:
@@ -32,12 +37,20 @@ Reading from stdin:
 
 Reading from a file:
   jsontoml file.json > file.toml
+
+Flags:
+  -use-json-number  unmarshal numbers into json.Number instead of float64
+  -keep-order       keep the keys of objects in the order of the document
 `
 
-var useJsonNumber bool
+var (
+	useJsonNumber bool
+	keepOrder     bool
+)
 
 func main() {
 	flag.BoolVar(&useJsonNumber, "use-json-number", false, "unmarshal numbers into `json.Number` type instead of as `float64`")
+	flag.BoolVar(&keepOrder, "keep-order", false, "keep the keys of objects in the order of the document instead of sorting them")
 
 	p := cli.Program{
 		Usage: usage,
@@ -57,10 +70,55 @@ func convert(r io.Reader, w io.Writer) error {
 		e.SetMarshalJsonNumbers(true)
 	}
 
-	err := d.Decode(&v)
+	var err error
+	if keepOrder {
+		v, err = decodeOrdered(d)
+	} else {
+		err = d.Decode(&v)
+	}
 	if err != nil {
 		return err
 	}
 
 	return e.Encode(v)
 }
+
+// decodeOrdered decodes the next JSON value of d, storing objects as
+// *toml.OrderedMap so that the order of their keys is kept.
+func decodeOrdered(d *json.Decoder) (interface{}, error) {
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		m := toml.NewOrderedMap()
+		for d.More() {
+			k, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrdered(d)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(k.(string), v)
+		}
+		_, err = d.Token()
+		return m, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for d.More() {
+			v, err := decodeOrdered(d)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err = d.Token()
+		return a, err
+	}
+
+	return tok, nil
+}
@@ -16,6 +16,7 @@ func TestConvert(t *testing.T) {
 		expected      string
 		errors        bool
 		useJsonNumber bool
+		keepOrder     bool
 	}{
 		{
 			name: "valid json",
@@ -40,6 +41,26 @@ a = 42.0
 }`,
 			expected: `[mytoml]
 a = 42
+`,
+		},
+		{
+			name:          "keep order",
+			useJsonNumber: true,
+			keepOrder:     true,
+			input: `
+{
+  "z": {"b": 1, "a": [{"y": true, "x": null}]},
+  "c": "value",
+  "a": [3, 1.5]
+}`,
+			expected: `c = 'value'
+a = [3, 1.5]
+
+[z]
+b = 1
+
+[[z.a]]
+y = true
 `,
 		},
 		{
@@ -47,11 +68,18 @@ a = 42
 			input:  `{ foo`,
 			errors: true,
 		},
+		{
+			name:      "invalid json keep order",
+			input:     `{"a": [1, }`,
+			keepOrder: true,
+			errors:    true,
+		},
 	}
 
 	for _, e := range examples {
 		b := new(bytes.Buffer)
 		useJsonNumber = e.useJsonNumber
+		keepOrder = e.keepOrder
 		err := convert(strings.NewReader(e.input), b)
 		if e.errors {
 			require.Error(t, err)
@@ -10,6 +10,11 @@
 //
 //	tomll a.toml b.toml c.toml
 //
+// Keeping the keys of tables in the order of the document instead of sorting
+// them:
+//
+//	tomll -keep-order a.toml
+//
 // # Installation
 //
 // Using Go:
@@ -18,6 +23,7 @@
 package main
 
 import (
+	"flag"
 	"io"
 
 	"github.com/pelletier/go-toml/v2"
@@ -33,9 +39,16 @@ Reading and updating a list of files in place:
   tomll a.toml b.toml c.toml
 
 When given a list of files, tomll will modify all files in place without asking.
+
+Flags:
+  -keep-order  keep the keys of tables in the order of the document
 `
 
+var keepOrder bool
+
 func main() {
+	flag.BoolVar(&keepOrder, "keep-order", false, "keep the keys of tables in the order of the document instead of sorting them")
+
 	p := cli.Program{
 		Usage:   usage,
 		Fn:      convert,
@@ -48,6 +61,10 @@ func convert(r io.Reader, w io.Writer) error {
 	var v interface{}
 
 	d := toml.NewDecoder(r)
+	if keepOrder {
+		d.UseOrderedMaps()
+	}
+
 	err := d.Decode(&v)
 	if err != nil {
 		return err
@@ -11,10 +11,11 @@ import (
 
 func TestConvert(t *testing.T) {
 	examples := []struct {
-		name     string
-		input    string
-		expected string
-		errors   bool
+		name      string
+		input     string
+		expected  string
+		errors    bool
+		keepOrder bool
 	}{
 		{
 			name: "valid toml",
@@ -23,6 +24,47 @@ mytoml.a = 42.0
 `,
 			expected: `[mytoml]
 a = 42.0
+`,
+		},
+		{
+			name: "sorted keys",
+			input: `
+b = 1
+a = 2
+[z]
+y = 1
+[c]
+x = 1
+`,
+			expected: `a = 2
+b = 1
+
+[c]
+x = 1
+
+[z]
+y = 1
+`,
+		},
+		{
+			name:      "keep order",
+			keepOrder: true,
+			input: `
+b = 1
+a = 2
+[z]
+y = 1
+[c]
+x = 1
+`,
+			expected: `b = 1
+a = 2
+
+[z]
+y = 1
+
+[c]
+x = 1
 `,
 		},
 		{
@@ -34,6 +76,7 @@ a = 42.0
 
 	for _, e := range examples {
 		b := new(bytes.Buffer)
+		keepOrder = e.keepOrder
 		err := convert(strings.NewReader(e.input), b)
 		if e.errors {
 			require.Error(t, err)
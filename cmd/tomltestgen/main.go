@@ -4,6 +4,13 @@
 // Within the go-toml package, run `go generate`.  Otherwise, use:
 //
 //	go run github.com/pelletier/go-toml/cmd/tomltestgen -o toml_testgen_test.go
+//
+// By default, the tests target TOML v1.0.0. Use -toml to select another
+// version of the specification. The tests are then limited to the files listed
+// in tests/files-toml-<version>, and the generated functions are suffixed with
+// the version:
+//
+//	go run github.com/pelletier/go-toml/cmd/tomltestgen -toml 1.1.0 -o toml_testgen_v11_test.go
 package main
 
 import (
@@ -34,6 +41,7 @@ type valid struct {
 type testsCollection struct {
 	Ref       string
 	Timestamp string
+	Suffix    string
 	Invalid   []invalid
 	Valid     []valid
 	Count     int
@@ -46,17 +54,17 @@ const srcTemplate = "// Generated by tomltestgen for toml-test ref {{.Ref}} on {
 	")\n" +
 
 	"{{range .Invalid}}\n" +
-	"func TestTOMLTest_Invalid_{{.Name}}(t *testing.T) {\n" +
+	"func TestTOMLTest{{$.Suffix}}_Invalid_{{.Name}}(t *testing.T) {\n" +
 	"	input := {{.Input|gostr}}\n" +
-	"	testgenInvalid(t, input)\n" +
+	"	testgenInvalid{{$.Suffix}}(t, input)\n" +
 	"}\n" +
 	"{{end}}\n" +
 	"\n" +
 	"{{range .Valid}}\n" +
-	"func TestTOMLTest_Valid_{{.Name}}(t *testing.T) {\n" +
+	"func TestTOMLTest{{$.Suffix}}_Valid_{{.Name}}(t *testing.T) {\n" +
 	"   input := {{.Input|gostr}}\n" +
 	"   jsonRef := {{.JsonRef|gostr}}\n" +
-	"   testgenValid(t, input, jsonRef)\n" +
+	"   testgenValid{{$.Suffix}}(t, input, jsonRef)\n" +
 	"}\n" +
 	"{{end}}\n"
 
@@ -84,10 +92,42 @@ func templateGoStr(input string) string {
 }
 
 var (
-	ref = flag.String("r", "master", "git reference")
-	out = flag.String("o", "", "output file")
+	ref     = flag.String("r", "master", "git reference")
+	out     = flag.String("o", "", "output file")
+	version = flag.String("toml", "1.0.0", "version of the TOML specification")
 )
 
+// versionSuffix returns the suffix of the generated functions for a version
+// of the specification: none for 1.0.0, 11 for 1.1.0.
+func versionSuffix(v string) string {
+	if v == "1.0.0" {
+		return ""
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(v, ".0"), ".", "")
+}
+
+// listFiles returns the test files of a kind (valid or invalid) that apply to
+// the selected version of the specification.
+func listFiles(kind string) ([]string, error) {
+	if *version == "1.0.0" {
+		return filepath.Glob("tests/" + kind + "/**/*.toml")
+	}
+
+	list, err := os.ReadFile("tests/files-toml-" + *version)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range strings.Split(string(list), "\n") {
+		if strings.HasPrefix(f, kind+"/") && strings.HasSuffix(f, ".toml") {
+			files = append(files, filepath.Join("tests", f))
+		}
+	}
+
+	return files, nil
+}
+
 func usage() {
 	_, _ = fmt.Fprintf(os.Stderr, "usage: tomltestgen [flags]\n")
 	flag.PrintDefaults()
@@ -100,9 +140,14 @@ func main() {
 	collection := testsCollection{
 		Ref:       *ref,
 		Timestamp: time.Now().Format(time.RFC3339),
+		Suffix:    versionSuffix(*version),
 	}
 
-	dirContent, _ := filepath.Glob("tests/invalid/**/*.toml")
+	dirContent, err := listFiles("invalid")
+	if err != nil {
+		fmt.Printf("failed to list test files: %s\n", err)
+		os.Exit(1)
+	}
 	for _, f := range dirContent {
 		filename := strings.TrimPrefix(f, "tests/valid/")
 		name := kebabToCamel(strings.TrimSuffix(filename, ".toml"))
@@ -122,7 +167,11 @@ func main() {
 		collection.Count++
 	}
 
-	dirContent, _ = filepath.Glob("tests/valid/**/*.toml")
+	dirContent, err = listFiles("valid")
+	if err != nil {
+		fmt.Printf("failed to list test files: %s\n", err)
+		os.Exit(1)
+	}
 	for _, f := range dirContent {
 		filename := strings.TrimPrefix(f, "tests/valid/")
 		name := kebabToCamel(strings.TrimSuffix(filename, ".toml"))
@@ -157,7 +206,7 @@ func main() {
 	}
 	t := template.Must(template.New("src").Funcs(funcMap).Parse(srcTemplate))
 	buf := new(bytes.Buffer)
-	err := t.Execute(buf, collection)
+	err = t.Execute(buf, collection)
 	if err != nil {
 		panic(err)
 	}
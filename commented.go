@@ -0,0 +1,90 @@
+package toml
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Commented wraps a value to encode it along with comments. It allows to
+// attach comments at runtime, for example to the values of a map, where the
+// comment struct tag cannot be used.
+//
+// Commented can be used as a struct field, a map or OrderedMap value, or an
+// element of a slice. It is encoded as its Value, and is not supported when
+// decoding.
+type Commented struct {
+	Value interface{}
+
+	// Comment is written on the lines preceding the key-value, table, or
+	// array table element. Inside arrays, the array is written with one
+	// element per line and Comment is written before the element. Comment
+	// may contain several lines. It replaces the comment struct tag.
+	Comment string
+
+	// Trailing is written at the end of the line of the key-value, table
+	// header, array table element header, or array element.
+	Trailing string
+}
+
+// commentedValue returns the Commented held by v, if any.
+func commentedValue(v reflect.Value) (Commented, bool) {
+	if !v.IsValid() {
+		return Commented{}, false
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Type() == commentedType:
+		return v.Interface().(Commented), true
+	case v.Type() == commentedPtrType && !v.IsNil():
+		return *v.Interface().(*Commented), true
+	default:
+		return Commented{}, false
+	}
+}
+
+// unwrapCommented returns the value wrapped by v if it is a Commented, and
+// sets its comments in options. Otherwise, it returns v.
+func unwrapCommented(v reflect.Value, options *valueOptions) reflect.Value {
+	c, ok := commentedValue(v)
+	if !ok {
+		return v
+	}
+
+	if c.Comment != "" {
+		options.comment = c.Comment
+	}
+	options.trailing = nil
+	if c.Trailing != "" {
+		trailing := c.Trailing
+		options.trailing = &trailing
+	}
+
+	if c.Value == nil {
+		return reflect.Zero(emptyInterfaceType)
+	}
+	return reflect.ValueOf(c.Value)
+}
+
+// hasCommentedElements returns true if an element of the slice or array v is
+// a Commented with comments.
+func hasCommentedElements(v reflect.Value) bool {
+	for i := 0; i < v.Len(); i++ {
+		c, ok := commentedValue(v.Index(i))
+		if ok && (c.Comment != "" || c.Trailing != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeTrailingComment writes comment at the end of the current line.
+func (enc *Encoder) encodeTrailingComment(comment string, b []byte) []byte {
+	if comment == "" {
+		return b
+	}
+	b = append(b, " # "...)
+	return append(b, strings.ReplaceAll(comment, "\n", " ")...)
+}
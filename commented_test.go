@@ -0,0 +1,174 @@
+package toml_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalCommentedValues(t *testing.T) {
+	type inline struct {
+		A int `comment:"not in inline tables"`
+	}
+
+	examples := []struct {
+		desc     string
+		v        interface{}
+		expected string
+	}{
+		{
+			desc: "map entries",
+			v: map[string]interface{}{
+				"port": toml.Commented{Value: 8080, Comment: "Port to listen on.\nDefaults to 8080.", Trailing: "http"},
+				"host": toml.Commented{Value: "localhost"},
+				"none": toml.Commented{Comment: "nil values are skipped"},
+				"ptr":  &toml.Commented{Value: true, Trailing: "pointer"},
+			},
+			expected: `host = 'localhost'
+# Port to listen on.
+# Defaults to 8080.
+port = 8080 # http
+ptr = true # pointer
+`,
+		},
+		{
+			desc: "tables",
+			v: map[string]interface{}{
+				"server": toml.Commented{
+					Value:    map[string]interface{}{"port": 80},
+					Comment:  "Server settings.",
+					Trailing: "required",
+				},
+			},
+			expected: `# Server settings.
+[server] # required
+port = 80
+`,
+		},
+		{
+			desc: "array tables",
+			v: map[string]interface{}{
+				"servers": toml.Commented{
+					Value: []interface{}{
+						toml.Commented{Value: map[string]interface{}{"name": "a"}, Trailing: "primary"},
+						map[string]interface{}{"name": "b"},
+						toml.Commented{Value: map[string]interface{}{"name": "c"}, Comment: "Backup."},
+					},
+					Comment: "Servers.",
+				},
+			},
+			expected: `# Servers.
+[[servers]] # primary
+name = 'a'
+
+[[servers]]
+name = 'b'
+
+# Backup.
+[[servers]]
+name = 'c'
+`,
+		},
+		{
+			desc: "array elements",
+			v: map[string]interface{}{
+				"ports": []interface{}{
+					toml.Commented{Value: 80, Comment: "HTTP"},
+					443,
+					toml.Commented{Value: 8080, Trailing: "alternate"},
+				},
+				"plain": []interface{}{toml.Commented{Value: 1}, 2},
+			},
+			expected: `plain = [1, 2]
+ports = [
+  # HTTP
+  80,
+  443,
+  8080 # alternate
+]
+`,
+		},
+		{
+			desc: "struct fields",
+			v: struct {
+				Tagged  toml.Commented `comment:"from the tag"`
+				Dynamic toml.Commented `comment:"replaced"`
+				Iface   interface{}
+			}{
+				Tagged:  toml.Commented{Value: 1},
+				Dynamic: toml.Commented{Value: 2, Comment: "at runtime"},
+				Iface:   toml.Commented{Value: 3, Trailing: "in an interface"},
+			},
+			expected: `# from the tag
+Tagged = 1
+# at runtime
+Dynamic = 2
+Iface = 3 # in an interface
+`,
+		},
+		{
+			desc: "ignored in inline tables",
+			v: struct {
+				Inline inline `toml:",inline"`
+				Values []interface{}
+			}{
+				Inline: inline{A: 1},
+				Values: []interface{}{0, map[string]interface{}{
+					"a": toml.Commented{Value: 1, Comment: "no", Trailing: "no"},
+				}},
+			},
+			expected: `Inline = {A = 1}
+Values = [0, {a = 1}]
+`,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			b, err := toml.Marshal(e.v)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, string(b))
+
+			var v interface{}
+			err = toml.Unmarshal(b, &v)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMarshalCommentedOrderedMap(t *testing.T) {
+	m := toml.NewOrderedMap()
+	m.Set("b", toml.Commented{Value: 1, Comment: "first"})
+	m.Set("a", toml.Commented{Value: 2, Trailing: "second"})
+
+	b, err := toml.Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, "# first\nb = 1\na = 2 # second\n", string(b))
+}
+
+func ExampleCommented() {
+	settings := map[string]interface{}{
+		"listen": toml.Commented{
+			Value:   "localhost:8080",
+			Comment: "Address the server listens on.",
+		},
+		"workers": toml.Commented{
+			Value:    4,
+			Trailing: "0 uses all the CPUs",
+		},
+	}
+
+	b, err := toml.Marshal(settings)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(b))
+	// Output:
+	// # Address the server listens on.
+	// listen = 'localhost:8080'
+	// workers = 4 # 0 uses all the CPUs
+}
@@ -0,0 +1,143 @@
+package toml
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/internal/tracker"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// Comments are the comments attached to the keys of a document. See
+// ExtractComments.
+type Comments struct {
+	keys []KeyComments
+
+	// Index in keys of the first occurrence of each key path.
+	index map[string]int
+}
+
+// KeyComments are the comments of a table, array table, or key-value.
+type KeyComments struct {
+	// Full path of the key, including the table it belongs to.
+	Key Key
+
+	// Position of the first byte of the key in the document.
+	Position Position
+
+	// Leading is the block of comment lines directly above the key, without
+	// blank lines in between. Lines are separated by '\n'.
+	Leading string
+
+	// Trailing is the comment following the key on the same line. For
+	// key-values, it follows the value.
+	Trailing string
+}
+
+// ExtractComments parses document and returns the comments attached to each
+// of its keys. It allows to generate documentation from annotated
+// configuration files.
+//
+// Comments are returned without their leading '#' and the space following it.
+// Comments that are not directly above a key, such as the ones separated from
+// the next key by a blank line, and the comments inside arrays, are not
+// attached to any key.
+//
+// If the document is not valid TOML, the returned error is a *DecodeError.
+func ExtractComments(document []byte) (*Comments, error) {
+	p := unstable.Parser{KeepComments: true}
+	p.Reset(document)
+
+	c := &Comments{index: map[string]int{}}
+
+	var (
+		positions positionTracker
+		key       tracker.KeyTracker
+		leading   []string
+		// Line of the last comment of leading.
+		leadingLine int
+	)
+	positions.Reset(document, 0, 1)
+
+	for p.NextExpression() {
+		expr := p.Expression()
+
+		if expr.Kind == unstable.Comment {
+			pos := positions.Position(int(expr.Raw.Offset))
+			if leadingLine != pos.Line-1 {
+				leading = leading[:0]
+			}
+			leading = append(leading, commentText(expr.Data))
+			leadingLine = pos.Line
+			continue
+		}
+
+		it := expr.Key()
+		it.Next()
+		pos := positions.Position(int(it.Node().Raw.Offset))
+
+		if expr.Kind == unstable.KeyValue {
+			key.Push(expr)
+		} else {
+			key.UpdateTable(expr)
+		}
+
+		kc := KeyComments{
+			Key:      key.Key(),
+			Position: pos,
+		}
+		if expr.Kind == unstable.KeyValue {
+			key.Pop(expr)
+		}
+		if len(leading) > 0 && leadingLine == pos.Line-1 {
+			kc.Leading = strings.Join(leading, "\n")
+		}
+		if n := expr.Next(); n != nil && n.Kind == unstable.Comment {
+			kc.Trailing = commentText(n.Data)
+		}
+		leading = leading[:0]
+
+		idx := metadataIndex(kc.Key)
+		if _, ok := c.index[idx]; !ok {
+			c.index[idx] = len(c.keys)
+		}
+		c.keys = append(c.keys, kc)
+	}
+
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) {
+			return nil, wrapDecodeError(document, perr)
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Keys returns the comments of all the tables, array tables, and key-values of
+// the document, in the order they appear, including the keys without
+// comments. Array tables are listed once per element.
+//
+// The returned slice must not be modified.
+func (c *Comments) Keys() []KeyComments {
+	return c.keys
+}
+
+// Get returns the comments of the first key of the document with the given
+// path.
+func (c *Comments) Get(key ...string) (KeyComments, bool) {
+	idx, ok := c.index[metadataIndex(key)]
+	if !ok {
+		return KeyComments{}, false
+	}
+	return c.keys[idx], true
+}
+
+// commentText returns the text of a comment, without the leading '#', the
+// space following it, and the line ending.
+func commentText(b []byte) string {
+	s := strings.TrimPrefix(string(b), "#")
+	s = strings.TrimPrefix(s, " ")
+	return strings.TrimRight(s, " \t\r")
+}
@@ -0,0 +1,123 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractComments(t *testing.T) {
+	doc := `# Configuration of the server.
+
+# Name of the server.
+# Must be unique.
+name = "web" # short name
+
+#indented
+  title = "x"
+ports = [
+  # comment in array
+  80,
+] # after array
+
+# Network settings.
+[network]   # trailing on header
+# Listen address.
+address = "0.0.0.0"
+tls.enabled = true # dotted
+
+# First plugin.
+[[plugins]]
+kind = "a"
+
+[[plugins]] # second
+kind = "b"
+`
+
+	c, err := toml.ExtractComments([]byte(doc))
+	require.NoError(t, err)
+
+	expected := []toml.KeyComments{
+		{Key: toml.Key{"name"}, Leading: "Name of the server.\nMust be unique.", Trailing: "short name"},
+		{Key: toml.Key{"title"}, Leading: "indented"},
+		{Key: toml.Key{"ports"}, Trailing: "after array"},
+		{Key: toml.Key{"network"}, Leading: "Network settings.", Trailing: "trailing on header"},
+		{Key: toml.Key{"network", "address"}, Leading: "Listen address."},
+		{Key: toml.Key{"network", "tls", "enabled"}, Trailing: "dotted"},
+		{Key: toml.Key{"plugins"}, Leading: "First plugin."},
+		{Key: toml.Key{"plugins", "kind"}},
+		{Key: toml.Key{"plugins"}, Trailing: "second"},
+		{Key: toml.Key{"plugins", "kind"}},
+	}
+
+	keys := c.Keys()
+	require.Len(t, keys, len(expected))
+	for i, e := range expected {
+		assert.Equal(t, e.Key, keys[i].Key)
+		assert.Equal(t, e.Leading, keys[i].Leading, "leading of %s", e.Key)
+		assert.Equal(t, e.Trailing, keys[i].Trailing, "trailing of %s", e.Key)
+	}
+
+	assert.Equal(t, 5, keys[0].Position.Line)
+	assert.Equal(t, 1, keys[0].Position.Column)
+	assert.Equal(t, 8, keys[1].Position.Line)
+	assert.Equal(t, 3, keys[1].Position.Column)
+
+	kc, ok := c.Get("plugins")
+	require.True(t, ok)
+	assert.Equal(t, "First plugin.", kc.Leading)
+
+	kc, ok = c.Get("network", "tls", "enabled")
+	require.True(t, ok)
+	assert.Equal(t, "dotted", kc.Trailing)
+
+	_, ok = c.Get("network", "tls")
+	assert.False(t, ok)
+}
+
+func TestExtractCommentsWindowsNewlines(t *testing.T) {
+	c, err := toml.ExtractComments([]byte("# a\r\n# b \r\nkey = 1 # c\r\n"))
+	require.NoError(t, err)
+
+	kc, ok := c.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "a\nb", kc.Leading)
+	assert.Equal(t, "c", kc.Trailing)
+}
+
+func TestExtractCommentsError(t *testing.T) {
+	_, err := toml.ExtractComments([]byte("# a\nkey = \n"))
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%v", err)
+	row, _ := derr.Position()
+	assert.Equal(t, 2, row)
+}
+
+func ExampleExtractComments() {
+	doc := `
+# Address the server listens on.
+listen = "localhost:8080"
+
+[log]
+# Minimum level of the messages.
+level = "info" # debug, info, or error
+`
+
+	c, err := toml.ExtractComments([]byte(doc))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, k := range c.Keys() {
+		fmt.Printf("%s: %q %q\n", strings.Join(k.Key, "."), k.Leading, k.Trailing)
+	}
+	// Output:
+	// listen: "Address the server listens on." ""
+	// log: "" ""
+	// log.level: "Minimum level of the messages." "debug, info, or error"
+}
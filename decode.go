@@ -1,6 +1,7 @@
 package toml
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -75,13 +76,13 @@ func parseDecimalDigits(b []byte) (int, error) {
 	return v, nil
 }
 
-func parseDateTime(b []byte) (time.Time, error) {
+func parseDateTime(b []byte, secondsOptional bool) (time.Time, error) {
 	// offset-date-time = full-date time-delim full-time
 	// full-time      = partial-time time-offset
 	// time-offset    = "Z" / time-numoffset
 	// time-numoffset = ( "+" / "-" ) time-hour ":" time-minute
 
-	dt, b, err := parseLocalDateTime(b)
+	dt, b, err := parseLocalDateTime(b, secondsOptional)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -157,7 +158,7 @@ func parseDateTime(b []byte) (time.Time, error) {
 	return t, nil
 }
 
-func parseLocalDateTime(b []byte) (LocalDateTime, []byte, error) {
+func parseLocalDateTime(b []byte, secondsOptional bool) (LocalDateTime, []byte, error) {
 	var dt LocalDateTime
 
 	const localDateTimeByteMinLen = 11
@@ -176,7 +177,7 @@ func parseLocalDateTime(b []byte) (LocalDateTime, []byte, error) {
 		return dt, nil, unstable.NewParserError(b[10:11], "datetime separator is expected to be T or a space")
 	}
 
-	t, rest, err := parseLocalTime(b[11:])
+	t, rest, err := parseLocalTime(b[11:], secondsOptional)
 	if err != nil {
 		return dt, nil, err
 	}
@@ -188,14 +189,23 @@ func parseLocalDateTime(b []byte) (LocalDateTime, []byte, error) {
 // parseLocalTime is a bit different because it also returns the remaining
 // []byte that is didn't need. This is to allow parseDateTime to parse those
 // remaining bytes as a timezone.
-func parseLocalTime(b []byte) (LocalTime, []byte, error) {
+//
+// When secondsOptional is true, the format HH:MM is also accepted, as allowed
+// by TOML 1.1.
+func parseLocalTime(b []byte, secondsOptional bool) (LocalTime, []byte, error) {
 	var (
 		nspow = [10]int{0, 1e8, 1e7, 1e6, 1e5, 1e4, 1e3, 1e2, 1e1, 1e0}
 		t     LocalTime
 	)
 
 	// check if b matches to have expected format HH:MM:SS[.NNNNNN]
-	const localTimeByteLen = 8
+	const (
+		localTimeByteLen          = 8
+		localTimeNoSecondsByteLen = 5
+	)
+	if secondsOptional && len(b) >= localTimeNoSecondsByteLen && (len(b) == localTimeNoSecondsByteLen || b[5] != ':') {
+		return parseLocalTimeNoSeconds(b)
+	}
 	if len(b) < localTimeByteLen {
 		return t, nil, unstable.NewParserError(b, "times are expected to have the format HH:MM:SS[.NNNNNN]")
 	}
@@ -279,6 +289,34 @@ func parseLocalTime(b []byte) (LocalTime, []byte, error) {
 	return t, b, nil
 }
 
+func parseLocalTimeNoSeconds(b []byte) (LocalTime, []byte, error) {
+	var (
+		t   LocalTime
+		err error
+	)
+
+	t.Hour, err = parseDecimalDigits(b[0:2])
+	if err != nil {
+		return t, nil, err
+	}
+	if t.Hour > 23 {
+		return t, nil, unstable.NewParserError(b[0:2], "hour cannot be greater 23")
+	}
+	if b[2] != ':' {
+		return t, nil, unstable.NewParserError(b[2:3], "expecting colon between hours and minutes")
+	}
+
+	t.Minute, err = parseDecimalDigits(b[3:5])
+	if err != nil {
+		return t, nil, err
+	}
+	if t.Minute > 59 {
+		return t, nil, unstable.NewParserError(b[3:5], "minutes cannot be greater 59")
+	}
+
+	return t, b[5:], nil
+}
+
 //nolint:cyclop
 func parseFloat(b []byte) (float64, error) {
 	if len(b) == 4 && (b[0] == '+' || b[0] == '-') && b[1] == 'n' && b[2] == 'a' && b[3] == 'n' {
@@ -338,7 +376,7 @@ func parseIntHex(b []byte) (int64, error) {
 
 	i, err := strconv.ParseInt(string(cleaned), 16, 64)
 	if err != nil {
-		return 0, unstable.NewParserError(b, "couldn't parse hexadecimal number: %w", err)
+		return 0, parseIntError(b, "hexadecimal", err)
 	}
 
 	return i, nil
@@ -352,7 +390,7 @@ func parseIntOct(b []byte) (int64, error) {
 
 	i, err := strconv.ParseInt(string(cleaned), 8, 64)
 	if err != nil {
-		return 0, unstable.NewParserError(b, "couldn't parse octal number: %w", err)
+		return 0, parseIntError(b, "octal", err)
 	}
 
 	return i, nil
@@ -366,12 +404,21 @@ func parseIntBin(b []byte) (int64, error) {
 
 	i, err := strconv.ParseInt(string(cleaned), 2, 64)
 	if err != nil {
-		return 0, unstable.NewParserError(b, "couldn't parse binary number: %w", err)
+		return 0, parseIntError(b, "binary", err)
 	}
 
 	return i, nil
 }
 
+// parseIntError returns the error reported for the integer b, written in the
+// given base, when strconv cannot parse it.
+func parseIntError(b []byte, base string, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return withErrorDetails(unstable.NewParserError(b, "number %s does not fit in a 64-bit integer", b), CodeOverflow, "integer", nil)
+	}
+	return unstable.NewParserError(b, "couldn't parse %s number: %w", base, err)
+}
+
 func isSign(b byte) bool {
 	return b == '+' || b == '-'
 }
@@ -394,7 +441,7 @@ func parseIntDec(b []byte) (int64, error) {
 
 	i, err := strconv.ParseInt(string(cleaned), 10, 64)
 	if err != nil {
-		return 0, unstable.NewParserError(b, "couldn't parse decimal number: %w", err)
+		return 0, parseIntError(b, "decimal", err)
 	}
 
 	return i, nil
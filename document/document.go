@@ -0,0 +1,592 @@
+// Package document provides a comment-preserving, editable representation of
+// a TOML document.
+//
+// A Document retains everything the parser sees: comments, whitespace, key
+// order, and the exact spelling of every key and value. Writing a Document
+// that has not been modified returns the original bytes. Editing a key only
+// rewrites the lines that hold it.
+//
+// *Unstable:* This package does not follow the compatibility guarantees of
+// semver. It can be changed or removed without a new major version being
+// issued.
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// Document is an editable TOML document.
+//
+// It is organized as a flat list of entries, one per top-level expression of
+// the document (key-value, table header, array table header, or comment),
+// plus entries holding the blank lines in between. Each entry owns the exact
+// bytes it was parsed from, so that unmodified entries are written back
+// untouched.
+//
+// Keys are addressed by their full path from the root of the document, like
+// toml.Key. When a key lives under an array table, the last element of the
+// array table is used, which matches how the decoder resolves sub-tables
+// defined after an array table.
+type Document struct {
+	entries []*entry
+
+	// Line ending of the document, used for the lines added to it.
+	newline []byte
+
+	// True while the changes made by Edit are applied.
+	editing bool
+}
+
+type entry struct {
+	// Kind of the expression. Invalid is used for blank lines.
+	kind unstable.Kind
+
+	// Key of the table header for Table and ArrayTable entries, and of the
+	// table containing the key-value for KeyValue and Comment entries.
+	table []string
+
+	// Dotted key of a KeyValue entry.
+	key []string
+
+	// Full bytes of the entry, including indentation, trailing comment, and
+	// the final newline.
+	raw []byte
+
+	// Offsets in raw of the last part of the key, and of the value of a
+	// KeyValue entry.
+	keyStart, keyEnd     int
+	valueStart, valueEnd int
+}
+
+// Parse creates a Document from the content of a TOML document.
+//
+// The document is fully validated. Any error is the same as the one returned
+// by toml.Unmarshal.
+func Parse(data []byte) (*Document, error) {
+	var v interface{}
+	err := toml.Unmarshal(data, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries reference the input, so it needs to be copied to outlive the
+	// caller's slice.
+	return build(append([]byte(nil), data...))
+}
+
+func build(data []byte) (*Document, error) {
+	p := unstable.Parser{KeepComments: true}
+	p.Reset(data)
+
+	d := &Document{newline: []byte("\n")}
+	if i := bytes.IndexByte(data, '\n'); i > 0 && data[i-1] == '\r' {
+		d.newline = []byte("\r\n")
+	}
+
+	// Table in which the upcoming expressions are defined.
+	var table []string
+
+	last := 0
+	var pending *entry
+
+	flush := func(end int) {
+		if pending == nil {
+			if end > last {
+				d.entries = append(d.entries, &entry{raw: data[last:end]})
+			}
+			last = end
+			return
+		}
+
+		// Split trailing blank lines in their own entry. The last line of an
+		// expression always contains a non-blank character.
+		raw := data[last:end]
+		cut := contentEnd(raw)
+		pending.raw = raw[:cut]
+		d.entries = append(d.entries, pending)
+		if cut < len(raw) {
+			d.entries = append(d.entries, &entry{raw: raw[cut:]})
+		}
+		pending = nil
+		last = end
+	}
+
+	for p.NextExpression() {
+		expr := p.Expression()
+
+		start := lineStart(data, expressionOffset(expr))
+		flush(start)
+
+		e := &entry{kind: expr.Kind}
+
+		switch expr.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			table = keyParts(expr)
+			e.table = table
+		case unstable.KeyValue:
+			e.table = table
+			e.key = keyParts(expr)
+		case unstable.Comment:
+			e.table = table
+		}
+
+		pending = e
+
+		// Offsets are computed relative to the start of the entry once its
+		// end is known, but the nodes are only valid until the next
+		// expression is parsed.
+		if expr.Kind == unstable.KeyValue {
+			locateKeyValue(&p, expr, e, start)
+		}
+	}
+
+	if err := p.Error(); err != nil {
+		// The document has already been validated, so this should not
+		// happen.
+		return nil, err
+	}
+
+	flush(len(data))
+
+	for _, e := range d.entries {
+		if e.kind == unstable.KeyValue {
+			e.finishKeyValue()
+		}
+	}
+
+	return d, nil
+}
+
+// locateKeyValue records the offsets of the last key part and the start of
+// the value of a KeyValue expression, relative to the start of the entry.
+// The end of the value is computed later by finishKeyValue.
+func locateKeyValue(p *unstable.Parser, expr *unstable.Node, e *entry, start int) {
+	it := expr.Key()
+	var lastKey *unstable.Node
+	for it.Next() {
+		lastKey = it.Node()
+	}
+
+	e.keyStart = int(lastKey.Raw.Offset) - start
+	e.keyEnd = e.keyStart + int(lastKey.Raw.Length)
+
+	// The value is located after the separator.
+	data := p.Data()
+	i := e.keyEnd + start
+	for data[i] != '=' {
+		i++
+	}
+	i++
+	for data[i] == ' ' || data[i] == '\t' {
+		i++
+	}
+	e.valueStart = i - start
+
+	// If the expression has a trailing comment, the value ends before it.
+	// Otherwise, the value ends with the content of the entry. Use -1 as a
+	// marker until the entry is complete.
+	e.valueEnd = -1
+	if c := expr.Next(); c != nil && c.Kind == unstable.Comment {
+		e.valueEnd = int(c.Raw.Offset) - start
+	}
+}
+
+func (e *entry) finishKeyValue() {
+	end := e.valueEnd
+	if end < 0 {
+		end = len(e.raw)
+	}
+	for end > e.valueStart {
+		c := e.raw[end-1]
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			break
+		}
+		end--
+	}
+	e.valueEnd = end
+}
+
+// expressionOffset returns the offset in the document of the first byte of a
+// top-level expression that is not whitespace.
+func expressionOffset(expr *unstable.Node) int {
+	switch expr.Kind {
+	case unstable.Comment:
+		return int(expr.Raw.Offset)
+	default:
+		it := expr.Key()
+		it.Next()
+		return int(it.Node().Raw.Offset)
+	}
+}
+
+func lineStart(data []byte, offset int) int {
+	return bytes.LastIndexByte(data[:offset], '\n') + 1
+}
+
+// contentEnd returns the offset right after the last line of b that contains
+// a non-whitespace character, including its newline.
+func contentEnd(b []byte) int {
+	end := len(b)
+	for end > 0 {
+		idx := bytes.LastIndexByte(b[:end-1], '\n')
+		line := b[idx+1 : end]
+		if len(bytes.TrimSpace(line)) > 0 {
+			return end
+		}
+		end = idx + 1
+	}
+	return end
+}
+
+func keyParts(expr *unstable.Node) []string {
+	var parts []string
+	it := expr.Key()
+	for it.Next() {
+		parts = append(parts, string(it.Node().Data))
+	}
+	return parts
+}
+
+// Bytes returns the TOML representation of the document.
+func (d *Document) Bytes() []byte {
+	var b []byte
+	for _, e := range d.entries {
+		b = append(b, e.raw...)
+	}
+	return b
+}
+
+// String returns the TOML representation of the document.
+func (d *Document) String() string {
+	return string(d.Bytes())
+}
+
+// Decode the document into v, using the same rules as toml.Unmarshal.
+func (d *Document) Decode(v interface{}) error {
+	return toml.Unmarshal(d.Bytes(), v)
+}
+
+// Get returns the raw TOML text of the value at the given key, exactly as it
+// is spelled in the document. The second return value is false if the key is
+// not defined by a key-value.
+func (d *Document) Get(key ...string) (string, bool) {
+	e := d.findKeyValue(key)
+	if e == nil {
+		return "", false
+	}
+	return string(e.raw[e.valueStart:e.valueEnd]), true
+}
+
+// Set the value at the given key.
+//
+// The value is encoded with the same rules as toml.Marshal, with tables
+// emitted inline. If the key already exists, only its value is rewritten and
+// the surrounding formatting and comments are preserved. Otherwise, a new
+// key-value is added at the end of the closest table that contains it.
+//
+// Set returns an error if the value cannot be encoded, or if the resulting
+// document would not be valid TOML. In that case the document is left
+// unmodified.
+func (d *Document) Set(key []string, v interface{}) error {
+	if len(key) == 0 {
+		return fmt.Errorf("toml: cannot set a value at the root of the document")
+	}
+
+	value, err := formatValue(v)
+	if err != nil {
+		return err
+	}
+
+	return d.edit(func() error {
+		e := d.findKeyValue(key)
+		if e != nil {
+			e.replaceValue(value)
+			return nil
+		}
+		d.insertKeyValue(key, value)
+		return nil
+	})
+}
+
+// Delete removes the key-value, table, or array table at the given key.
+// Deleting a table removes its header and all the expressions it contains.
+// Deleting an array table removes its last element. It returns false if the
+// key could not be found.
+func (d *Document) Delete(key ...string) bool {
+	if e := d.findKeyValue(key); e != nil {
+		d.remove(e, e)
+		return true
+	}
+
+	idx := d.findTable(key)
+	if idx < 0 {
+		return false
+	}
+
+	end := idx + 1
+	for end < len(d.entries) && d.entries[end].kind != unstable.Table && d.entries[end].kind != unstable.ArrayTable {
+		end++
+	}
+
+	// Comments and blank lines right before the next table are more likely
+	// to be about that table, so they are kept.
+	if end < len(d.entries) {
+		for end > idx+1 && (d.entries[end-1].kind == unstable.Invalid || d.entries[end-1].kind == unstable.Comment) {
+			end--
+		}
+	}
+
+	d.entries = append(d.entries[:idx], d.entries[end:]...)
+	return true
+}
+
+// Rename changes the name of the last part of the given key to name. Only
+// key-values can be renamed.
+//
+// Rename returns an error if the key does not exist or if the resulting
+// document would not be valid TOML (for example when the new name is already
+// used). In that case the document is left unmodified.
+func (d *Document) Rename(key []string, name string) error {
+	return d.edit(func() error {
+		e := d.findKeyValue(key)
+		if e == nil {
+			return fmt.Errorf("toml: key %s not found", strings.Join(key, "."))
+		}
+		e.replaceKey(name)
+		return nil
+	})
+}
+
+// Edit applies the changes made by fn to the document, and checks once that
+// the result is a valid TOML document. Set and Rename do not check the
+// document when called by fn, which makes Edit cheaper than calling them
+// separately when making several changes.
+//
+// If fn returns an error, or if the resulting document would not be valid
+// TOML, the document is restored to its state before Edit was called and the
+// error is returned.
+func (d *Document) Edit(fn func() error) error {
+	return d.edit(fn)
+}
+
+// edit applies fn to the document and checks that the result is a valid TOML
+// document. On failure, the document is restored to its previous state. When
+// called by Edit, the document is checked by Edit instead.
+func (d *Document) edit(fn func() error) error {
+	if d.editing {
+		return fn()
+	}
+	d.editing = true
+	defer func() {
+		d.editing = false
+	}()
+
+	saved := make([]*entry, len(d.entries))
+	for i, e := range d.entries {
+		c := *e
+		saved[i] = &c
+	}
+
+	err := fn()
+	if err == nil {
+		var v interface{}
+		err = toml.Unmarshal(d.Bytes(), &v)
+	}
+
+	if err != nil {
+		d.entries = saved
+	}
+
+	return err
+}
+
+func (d *Document) remove(from, to *entry) {
+	for i, e := range d.entries {
+		if e != from {
+			continue
+		}
+		j := i
+		for d.entries[j] != to {
+			j++
+		}
+		d.entries = append(d.entries[:i], d.entries[j+1:]...)
+		return
+	}
+}
+
+func (d *Document) findKeyValue(key []string) *entry {
+	var found *entry
+	for _, e := range d.entries {
+		if e.kind != unstable.KeyValue || len(e.table)+len(e.key) != len(key) {
+			continue
+		}
+		if hasPrefix(key, e.table) && equal(key[len(e.table):], e.key) {
+			found = e
+		}
+	}
+	return found
+}
+
+func (d *Document) findTable(key []string) int {
+	found := -1
+	for i, e := range d.entries {
+		if (e.kind == unstable.Table || e.kind == unstable.ArrayTable) && equal(e.table, key) {
+			found = i
+		}
+	}
+	return found
+}
+
+// insertKeyValue adds a new key-value at the end of the table that is the
+// longest prefix of key. The root table is used if there is none.
+func (d *Document) insertKeyValue(key []string, value []byte) {
+	var table []string
+	header := -1
+	for i, e := range d.entries {
+		if e.kind != unstable.Table && e.kind != unstable.ArrayTable {
+			continue
+		}
+		if len(e.table) < len(key) && hasPrefix(key, e.table) && len(e.table) >= len(table) {
+			table = e.table
+			header = i
+		}
+	}
+
+	// Find the end of the section: after its last key-value, or right after
+	// its header.
+	pos := header + 1
+	indent := []byte(nil)
+	if header >= 0 {
+		indent = leadingWhitespace(d.entries[header].raw)
+	}
+	for i := header + 1; i < len(d.entries); i++ {
+		e := d.entries[i]
+		if e.kind == unstable.Table || e.kind == unstable.ArrayTable {
+			break
+		}
+		if e.kind == unstable.KeyValue {
+			pos = i + 1
+			indent = leadingWhitespace(e.raw)
+		}
+	}
+
+	if pos > 0 {
+		prev := d.entries[pos-1]
+		if len(prev.raw) > 0 && prev.raw[len(prev.raw)-1] != '\n' {
+			prev.raw = append(prev.raw[:len(prev.raw):len(prev.raw)], d.newline...)
+		}
+	}
+
+	var raw []byte
+	raw = append(raw, indent...)
+	e := &entry{
+		kind:  unstable.KeyValue,
+		table: table,
+		key:   key[len(table):],
+	}
+	for i, k := range e.key {
+		if i > 0 {
+			raw = append(raw, '.')
+		}
+		e.keyStart = len(raw)
+		raw = appendKey(raw, k)
+		e.keyEnd = len(raw)
+	}
+	raw = append(raw, " = "...)
+	e.valueStart = len(raw)
+	raw = append(raw, value...)
+	e.valueEnd = len(raw)
+	raw = append(raw, d.newline...)
+	e.raw = raw
+
+	d.entries = append(d.entries, nil)
+	copy(d.entries[pos+1:], d.entries[pos:])
+	d.entries[pos] = e
+}
+
+func (e *entry) replaceValue(value []byte) {
+	var raw []byte
+	raw = append(raw, e.raw[:e.valueStart]...)
+	raw = append(raw, value...)
+	raw = append(raw, e.raw[e.valueEnd:]...)
+	e.valueEnd = e.valueStart + len(value)
+	e.raw = raw
+}
+
+func (e *entry) replaceKey(name string) {
+	var raw []byte
+	raw = append(raw, e.raw[:e.keyStart]...)
+	raw = appendKey(raw, name)
+	delta := len(raw) - e.keyEnd
+	raw = append(raw, e.raw[e.keyEnd:]...)
+	e.keyEnd += delta
+	e.valueStart += delta
+	e.valueEnd += delta
+	e.raw = raw
+	e.key = append(e.key[:len(e.key)-1:len(e.key)-1], name)
+}
+
+func leadingWhitespace(b []byte) []byte {
+	i := 0
+	for i < len(b) && (b[i] == ' ' || b[i] == '\t') {
+		i++
+	}
+	return b[:i]
+}
+
+// formatValue returns the TOML representation of v as the right-hand side of
+// a key-value.
+func formatValue(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.SetTablesInline(true)
+	err := enc.Encode(map[string]interface{}{"v": v})
+	if err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	b = bytes.TrimPrefix(b, []byte("v = "))
+	b = bytes.TrimSuffix(b, []byte("\n"))
+	return b, nil
+}
+
+func appendKey(b []byte, k string) []byte {
+	bare := len(k) > 0
+	for _, c := range k {
+		if !((c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			bare = false
+			break
+		}
+	}
+	if bare {
+		return append(b, k...)
+	}
+
+	// Strings are quoted the same way as keys.
+	quoted, err := formatValue(k)
+	if err != nil {
+		panic(fmt.Errorf("strings should always be encodable: %w", err))
+	}
+	return append(b, quoted...)
+}
+
+func hasPrefix(key, prefix []string) bool {
+	return len(key) >= len(prefix) && equal(key[:len(prefix)], prefix)
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
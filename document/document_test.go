@@ -0,0 +1,310 @@
+package document_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `# This is a config file.
+
+title = "example" # the title
+count = 0x10
+
+[server]
+  # where to listen
+  host = 'localhost'
+  ports = [
+    8080, # http
+    8443,
+  ]
+
+[[plugins]]
+name = "a"
+
+[[plugins]]
+name = "b"
+enabled = true`
+
+func TestRoundTripUnchanged(t *testing.T) {
+	examples := []string{
+		``,
+		"\n\n",
+		sample,
+		"a = 1\r\nb = 2\r\n",
+		"a.b . c = 1 # comment\n\n\n# last\n",
+		"x = \"\"\"\nmulti\n\n\"\"\"\n\n[t]\n",
+	}
+
+	for i, e := range examples {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			d, err := document.Parse([]byte(e))
+			require.NoError(t, err)
+			assert.Equal(t, e, d.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := document.Parse([]byte("a = 1\na = 2"))
+	require.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	d, err := document.Parse([]byte(sample))
+	require.NoError(t, err)
+
+	examples := []struct {
+		key   []string
+		value string
+		found bool
+	}{
+		{key: []string{"title"}, value: `"example"`, found: true},
+		{key: []string{"count"}, value: `0x10`, found: true},
+		{key: []string{"server", "host"}, value: `'localhost'`, found: true},
+		{key: []string{"server", "ports"}, value: "[\n    8080, # http\n    8443,\n  ]", found: true},
+		{key: []string{"plugins", "name"}, value: `"b"`, found: true},
+		{key: []string{"server"}, found: false},
+		{key: []string{"missing"}, found: false},
+	}
+
+	for _, e := range examples {
+		v, ok := d.Get(e.key...)
+		assert.Equal(t, e.found, ok, e.key)
+		assert.Equal(t, e.value, v, e.key)
+	}
+}
+
+func TestSet(t *testing.T) {
+	examples := []struct {
+		desc     string
+		input    string
+		key      []string
+		value    interface{}
+		expected string
+		err      bool
+	}{
+		{
+			desc:     "existing value keeps comment",
+			input:    "a = 1 # one\nb = 2\n",
+			key:      []string{"a"},
+			value:    42,
+			expected: "a = 42 # one\nb = 2\n",
+		},
+		{
+			desc:     "existing value in table",
+			input:    "[t]\n  a = 'x'\n",
+			key:      []string{"t", "a"},
+			value:    "hello world",
+			expected: "[t]\n  a = 'hello world'\n",
+		},
+		{
+			desc:     "new value at root before tables",
+			input:    "a = 1\n\n[t]\nb = 2\n",
+			key:      []string{"c"},
+			value:    true,
+			expected: "a = 1\nc = true\n\n[t]\nb = 2\n",
+		},
+		{
+			desc:     "new value in table with indentation",
+			input:    "[t]\n  b = 2\n\n[u]\n",
+			key:      []string{"t", "c"},
+			value:    []int{1, 2},
+			expected: "[t]\n  b = 2\n  c = [1, 2]\n\n[u]\n",
+		},
+		{
+			desc:     "new value uses dotted key",
+			input:    "[t]\n",
+			key:      []string{"t", "x", "y z"},
+			value:    1.5,
+			expected: "[t]\nx.'y z' = 1.5\n",
+		},
+		{
+			desc:     "new value without trailing newline",
+			input:    "a = 1",
+			key:      []string{"b"},
+			value:    map[string]int{"x": 1},
+			expected: "a = 1\nb = {x = 1}\n",
+		},
+		{
+			desc:     "new value keeps line endings",
+			input:    "a = 1\r\n\r\n[t]\r\nb = 2",
+			key:      []string{"t", "c"},
+			value:    3,
+			expected: "a = 1\r\n\r\n[t]\r\nb = 2\r\nc = 3\r\n",
+		},
+		{
+			desc:  "conflict with table",
+			input: "[a]\nb = 1\n",
+			key:   []string{"a"},
+			value: 1,
+			err:   true,
+		},
+	}
+
+	for _, e := range examples {
+		t.Run(e.desc, func(t *testing.T) {
+			d, err := document.Parse([]byte(e.input))
+			require.NoError(t, err)
+
+			err = d.Set(e.key, e.value)
+			if e.err {
+				require.Error(t, err)
+				assert.Equal(t, e.input, d.String())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, d.String())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	examples := []struct {
+		desc     string
+		input    string
+		key      []string
+		expected string
+		found    bool
+	}{
+		{
+			desc:     "key value",
+			input:    "a = 1 # one\nb = 2\n",
+			key:      []string{"a"},
+			expected: "b = 2\n",
+			found:    true,
+		},
+		{
+			desc:     "table",
+			input:    "a = 1\n\n[t]\nx = 1\n# about u\n\n[u]\ny = 2\n",
+			key:      []string{"t"},
+			expected: "a = 1\n\n# about u\n\n[u]\ny = 2\n",
+			found:    true,
+		},
+		{
+			desc:     "last array table element",
+			input:    "[[p]]\nx = 1\n\n[[p]]\nx = 2\n",
+			key:      []string{"p"},
+			expected: "[[p]]\nx = 1\n\n",
+			found:    true,
+		},
+		{
+			desc:     "missing",
+			input:    "a = 1\n",
+			key:      []string{"b"},
+			expected: "a = 1\n",
+		},
+	}
+
+	for _, e := range examples {
+		t.Run(e.desc, func(t *testing.T) {
+			d, err := document.Parse([]byte(e.input))
+			require.NoError(t, err)
+
+			assert.Equal(t, e.found, d.Delete(e.key...))
+			assert.Equal(t, e.expected, d.String())
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	d, err := document.Parse([]byte("[t]\nold.name  =  1 # keep\nother = 2\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, d.Rename([]string{"t", "old", "name"}, "new name"))
+	assert.Equal(t, "[t]\nold.'new name'  =  1 # keep\nother = 2\n", d.String())
+
+	v, ok := d.Get("t", "old", "new name")
+	assert.True(t, ok)
+	assert.Equal(t, "1", v)
+
+	require.NoError(t, d.Set([]string{"t", "old", "new name"}, 2))
+	assert.Equal(t, "[t]\nold.'new name'  =  2 # keep\nother = 2\n", d.String())
+
+	err = d.Rename([]string{"t", "other"}, "old")
+	require.Error(t, err)
+
+	err = d.Rename([]string{"t", "missing"}, "x")
+	require.Error(t, err)
+}
+
+func TestEdit(t *testing.T) {
+	d, err := document.Parse([]byte("a = 1\nb = 2\n"))
+	require.NoError(t, err)
+
+	// The document is only checked once all the changes are made, so the
+	// keys can be swapped.
+	err = d.Edit(func() error {
+		if err := d.Rename([]string{"a"}, "b"); err != nil {
+			return err
+		}
+		if err := d.Rename([]string{"b"}, "a"); err != nil {
+			return err
+		}
+		return d.Set([]string{"c"}, 3)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b = 1\na = 2\nc = 3\n", d.String())
+
+	err = d.Edit(func() error {
+		if err := d.Set([]string{"d"}, 4); err != nil {
+			return err
+		}
+		return d.Rename([]string{"d"}, "a")
+	})
+	require.Error(t, err)
+	assert.Equal(t, "b = 1\na = 2\nc = 3\n", d.String())
+}
+
+func TestDecode(t *testing.T) {
+	d, err := document.Parse([]byte(sample))
+	require.NoError(t, err)
+
+	require.NoError(t, d.Set([]string{"server", "host"}, "example.com"))
+
+	var cfg struct {
+		Title  string
+		Server struct {
+			Host  string
+			Ports []int
+		}
+	}
+	require.NoError(t, d.Decode(&cfg))
+	assert.Equal(t, "example", cfg.Title)
+	assert.Equal(t, "example.com", cfg.Server.Host)
+	assert.Equal(t, []int{8080, 8443}, cfg.Server.Ports)
+}
+
+func ExampleDocument() {
+	doc := []byte(`# Server configuration
+[server]
+host = "localhost" # change me
+port = 8080
+`)
+
+	d, err := document.Parse(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	err = d.Set([]string{"server", "host"}, "example.com")
+	if err != nil {
+		panic(err)
+	}
+
+	err = d.Set([]string{"server", "timeout"}, 30)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(d)
+	// Output:
+	// # Server configuration
+	// [server]
+	// host = 'example.com' # change me
+	// port = 8080
+	// timeout = 30
+}
@@ -1,11 +1,15 @@
 package toml
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/pelletier/go-toml/v2/internal/danger"
+	"github.com/pelletier/go-toml/v2/internal/tracker"
 	"github.com/pelletier/go-toml/v2/unstable"
 )
 
@@ -20,10 +24,90 @@ type DecodeError struct {
 	line    int
 	column  int
 	key     Key
+	source  string
+
+	code     ErrorCode
+	found    string
+	expected reflect.Type
+	start    int
+	end      int
 
 	human string
 }
 
+// ErrorCode identifies the kind of problem reported by a DecodeError.
+type ErrorCode int
+
+const (
+	// CodeOther is an error that does not fit the other codes, such as an
+	// error returned by an UnmarshalText method, or created with
+	// NewDecodeError.
+	CodeOther ErrorCode = iota
+	// CodeSyntax is an error in the syntax of the document, including values
+	// that are not valid, like a date with 13 months.
+	CodeSyntax
+	// CodeTypeMismatch is a value of the document that cannot be stored in
+	// the Go type of the target.
+	CodeTypeMismatch
+	// CodeOverflow is a number that does not fit in the Go type of the
+	// target.
+	CodeOverflow
+	// CodeDuplicateKey is a key or a table defined more than once.
+	CodeDuplicateKey
+	// CodeUnknownField is a key of the document that does not have a
+	// corresponding field in the target, when DisallowUnknownFields() was
+	// called.
+	CodeUnknownField
+)
+
+// String implementation of fmt.Stringer.
+func (c ErrorCode) String() string {
+	switch c {
+	case CodeOther:
+		return "Other"
+	case CodeSyntax:
+		return "Syntax"
+	case CodeTypeMismatch:
+		return "TypeMismatch"
+	case CodeOverflow:
+		return "Overflow"
+	case CodeDuplicateKey:
+		return "DuplicateKey"
+	case CodeUnknownField:
+		return "UnknownField"
+	}
+	panic(fmt.Errorf("ErrorCode.String() not implemented for '%d'", c))
+}
+
+// errorDetails annotates a ParserError with the details reported by the
+// DecodeError it becomes.
+type errorDetails struct {
+	perr     *unstable.ParserError
+	code     ErrorCode
+	found    string
+	expected reflect.Type
+}
+
+func (e *errorDetails) Error() string {
+	return e.perr.Error()
+}
+
+func (e *errorDetails) Unwrap() error {
+	return e.perr
+}
+
+// withErrorDetails annotates err, which must be a *unstable.ParserError, with
+// the code of the error, the kind of TOML value found in the document, and
+// the expected Go type, if known.
+func withErrorDetails(err error, code ErrorCode, found string, expected reflect.Type) error {
+	return &errorDetails{
+		perr:     err.(*unstable.ParserError),
+		code:     code,
+		found:    found,
+		expected: expected,
+	}
+}
+
 // StrictMissingError occurs in a TOML document that does not have a
 // corresponding field in the target value. It contains all the missing fields
 // in Errors.
@@ -54,10 +138,214 @@ func (s *StrictMissingError) String() string {
 	return buf.String()
 }
 
+// RequiredMissingError occurs when struct fields with the required option have
+// no corresponding key in the TOML document. It contains all the missing
+// fields in Fields.
+//
+// Required fields of a nested struct are only checked if the struct is stored
+// by value, or through a non-nil pointer.
+type RequiredMissingError struct {
+	// One entry per missing field, in the order of the struct fields.
+	Fields []MissingField
+}
+
+// MissingField is a required struct field missing from the document.
+type MissingField struct {
+	// Path of the field from the decoded value, for example Items[1].Name.
+	Field string
+
+	// Key of the field in the document, for example items.name.
+	Key Key
+}
+
+// Error returns the canonical string for this error.
+func (e *RequiredMissingError) Error() string {
+	if len(e.Fields) == 1 {
+		return fmt.Sprintf("toml: required field %s is missing from the document", e.Fields[0].Field)
+	}
+	return fmt.Sprintf("toml: %d required fields are missing from the document", len(e.Fields))
+}
+
+// String returns a human readable description of all the missing fields, one
+// per line.
+func (e *RequiredMissingError) String() string {
+	var buf strings.Builder
+
+	for i, f := range e.Fields {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+
+		buf.WriteString(f.Field)
+		buf.WriteString(" (")
+		buf.WriteString(strings.Join(f.Key, "."))
+		buf.WriteString(")")
+	}
+
+	return buf.String()
+}
+
+// CycleError occurs when encoding a value that contains itself, through
+// pointers, maps, or slices. Such a value cannot be represented in TOML.
+type CycleError struct {
+	// Path of the Go value containing itself, from the encoded value, for
+	// example Next.Next or ["parent"].
+	Path string
+
+	// Type of the value containing itself.
+	Type reflect.Type
+}
+
+// Error returns the canonical string for this error.
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("toml: value of type %s at %s contains itself", e.Type, e.Path)
+}
+
+// EncodeError represents an error encountered while encoding a value.
+//
+// In addition to the error message, it contains the key of the document that
+// was being written, and the path of the Go value that could not be encoded.
+type EncodeError struct {
+	message string
+	key     Key
+	field   string
+	typ     reflect.Type
+	err     error
+}
+
+// Error returns the canonical string for this error.
+func (e *EncodeError) Error() string {
+	var buf strings.Builder
+
+	buf.WriteString("toml: ")
+	buf.WriteString(e.message)
+
+	switch {
+	case len(e.key) > 0 && e.field != "":
+		fmt.Fprintf(&buf, " (key %s, field %s)", strings.Join(e.key, "."), e.field)
+	case len(e.key) > 0:
+		fmt.Fprintf(&buf, " (key %s)", strings.Join(e.key, "."))
+	case e.field != "":
+		fmt.Fprintf(&buf, " (field %s)", e.field)
+	}
+
+	return buf.String()
+}
+
+// Key of the document that was being written when the error occurred. It is
+// empty if the error occurred at the root of the document.
+func (e *EncodeError) Key() Key {
+	return e.key
+}
+
+// Field is the path of the value that could not be encoded, from the encoded
+// value, for example Servers[1].Port or ["servers"][1]["port"]. It is empty if
+// the error occurred on the encoded value itself.
+func (e *EncodeError) Field() string {
+	return e.field
+}
+
+// Type of the value that could not be encoded.
+func (e *EncodeError) Type() reflect.Type {
+	return e.typ
+}
+
+// Unwrap returns the error that caused this error, such as an error returned
+// by a MarshalText method, or a *CycleError. It returns nil if there is none.
+func (e *EncodeError) Unwrap() error {
+	return e.err
+}
+
+// DecodeErrors occurs when values of a TOML document could not be decoded
+// into the target value. It contains one error per value.
+//
+// Emitted by Decoder when CollectErrors() was called, or when both fields of
+// the document are missing in the target in strict mode and required fields
+// are missing in the document. Errors is empty in the latter case.
+type DecodeErrors struct {
+	// One error per value that could not be decoded, in the order they appear
+	// in the document.
+	Errors []DecodeError
+
+	// Fields of the document that are missing in the target, when
+	// DisallowUnknownFields() was called as well. Nil if there are none.
+	Missing *StrictMissingError
+
+	// Required fields of the target that are missing in the document. Nil if
+	// there are none.
+	Required *RequiredMissingError
+}
+
+// Error returns the canonical string for this error.
+func (e *DecodeErrors) Error() string {
+	switch len(e.Errors) {
+	case 0:
+		var msgs []string
+		if e.Missing != nil {
+			msgs = append(msgs, e.Missing.Error())
+		}
+		if e.Required != nil {
+			msgs = append(msgs, strings.TrimPrefix(e.Required.Error(), "toml: "))
+		}
+		return "toml: " + strings.Join(msgs, "; ")
+	case 1:
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("toml: %d values could not be decoded", len(e.Errors))
+}
+
+// String returns a human readable description of all errors, including the
+// missing fields.
+func (e *DecodeErrors) String() string {
+	var buf strings.Builder
+
+	for i, e := range e.Errors {
+		if i > 0 {
+			buf.WriteString("\n---\n")
+		}
+
+		buf.WriteString(e.String())
+	}
+
+	if e.Missing != nil {
+		buf.WriteString("\n---\n")
+		buf.WriteString(e.Missing.String())
+	}
+
+	if e.Required != nil {
+		buf.WriteString("\n---\n")
+		buf.WriteString(e.Required.String())
+	}
+
+	return buf.String()
+}
+
+// Unwrap returns the StrictMissingError listing the missing fields, if any.
+func (e *DecodeErrors) Unwrap() error {
+	if e.Missing == nil {
+		return nil
+	}
+	return e.Missing
+}
+
+// As lets errors.As find the RequiredMissingError listing the missing required
+// fields, if any.
+func (e *DecodeErrors) As(target interface{}) bool {
+	if t, ok := target.(**RequiredMissingError); ok && e.Required != nil {
+		*t = e.Required
+		return true
+	}
+	return false
+}
+
 type Key []string
 
-// Error returns the error message contained in the DecodeError.
+// Error returns the error message contained in the DecodeError. It is
+// prefixed with the name of the document when known.
 func (e *DecodeError) Error() string {
+	if e.source != "" {
+		return "toml: " + e.source + ": " + e.message
+	}
 	return "toml: " + e.message
 }
 
@@ -72,12 +360,174 @@ func (e *DecodeError) Position() (row int, column int) {
 	return e.line, e.column
 }
 
-// Key that was being processed when the error occurred. The key is present only
-// if this DecodeError is part of a StrictMissingError.
+// Source returns the name of the document the error occurred in. It is empty
+// unless the document was named, for example when using a Merger.
+func (e *DecodeError) Source() string {
+	return e.source
+}
+
+// Key that was being processed when the error occurred. For errors returned by
+// the Decoder, it is the full key of the value that could not be decoded, or of
+// the table being decoded when the document is not valid. It is empty for
+// errors created by NewDecodeError.
 func (e *DecodeError) Key() Key {
 	return e.key
 }
 
+// Code identifies the kind of error.
+func (e *DecodeError) Code() ErrorCode {
+	return e.code
+}
+
+// Found returns the kind of TOML value found in the document, such as integer,
+// string, array, inline table, or table. It is set for errors with the codes
+// CodeTypeMismatch, CodeOverflow, and CodeUnknownField, for the redefinition
+// of errors with the code CodeDuplicateKey, and for errors with the code
+// CodeOther about a value of the document. It is empty for errors with the
+// code CodeSyntax, and errors created by NewDecodeError or NewKeyDecodeError.
+func (e *DecodeError) Found() string {
+	return e.found
+}
+
+// Expected returns the Go type the value of the document was decoded into. It
+// is set for errors with the codes CodeTypeMismatch and CodeOverflow, and for
+// errors with the code CodeOther returned while decoding into a Go value, like
+// the errors of UnmarshalText methods. It is nil otherwise.
+func (e *DecodeError) Expected() reflect.Type {
+	return e.expected
+}
+
+// Range returns the offsets in the document of the highlighted bytes, from
+// start (included) to end (excluded). Only the first line of a value spanning
+// several lines is highlighted.
+func (e *DecodeError) Range() (start, end int) {
+	return e.start, e.end
+}
+
+// NewDecodeError creates a DecodeError that highlights the bytes of document
+// between the offsets start (included) and end (excluded), with the given
+// message. Only the first line of the range is highlighted.
+//
+// It allows to report errors found after decoding, for example when validating
+// the decoded values, the same way errors from the decoder are. The location of
+// decoded values can be retrieved with Decoder.RecordMetadata.
+//
+// It panics if the range is not within document.
+func NewDecodeError(document []byte, start, end int, message string) *DecodeError {
+	derr := wrapDecodeErrorOffset(document, 1, 0, start, &unstable.ParserError{
+		Highlight: firstLine(document[start:end]),
+		Message:   message,
+	})
+	derr.code = CodeOther
+	return derr
+}
+
+// NewKeyDecodeError creates a DecodeError for the given key of document, with
+// the given message. The value of the first key-value matching key is
+// highlighted. If key is a table or an array table, its header is highlighted
+// instead.
+//
+// An error is returned if the document cannot be parsed, or if it does not
+// contain key.
+func NewKeyDecodeError(document []byte, key Key, message string) (*DecodeError, error) {
+	p := unstable.Parser{}
+	p.Reset(document)
+
+	var kt tracker.KeyTracker
+
+	for p.NextExpression() {
+		expr := p.Expression()
+
+		var highlight []byte
+		switch expr.Kind {
+		case unstable.Table:
+			kt.UpdateTable(expr)
+			if keyEqual(kt.Key(), key) {
+				highlight = keyLocation(expr)
+			}
+		case unstable.ArrayTable:
+			kt.UpdateArrayTable(expr)
+			if keyEqual(kt.Key(), key) {
+				highlight = keyLocation(expr)
+			}
+		case unstable.KeyValue:
+			highlight = findKeyValue(&p, &kt, expr, key)
+		}
+
+		if highlight != nil {
+			derr := wrapDecodeError(document, &unstable.ParserError{
+				Highlight: firstLine(highlight),
+				Message:   message,
+				Key:       key,
+			})
+			derr.code = CodeOther
+			return derr, nil
+		}
+	}
+
+	var perr *unstable.ParserError
+	if errors.As(p.Error(), &perr) {
+		return nil, wrapDecodeError(document, perr)
+	}
+
+	return nil, fmt.Errorf("toml: key %s not found in document", strings.Join(key, "."))
+}
+
+// findKeyValue returns the raw value of the key-value node, or of one of the
+// key-values of its inline tables, that matches key. If key designates a table
+// defined by the dotted key of the key-value, the parts of the dotted key
+// making key are returned instead.
+func findKeyValue(p *unstable.Parser, kt *tracker.KeyTracker, node *unstable.Node, key Key) []byte {
+	parent := len(kt.Key())
+	kt.Push(node)
+	defer kt.Pop(node)
+
+	value := node.Value()
+	if keyEqual(kt.Key(), key) {
+		return p.Raw(value.Extent())
+	}
+
+	if len(key) > parent && len(key) < len(kt.Key()) && keyEqual(kt.Key()[:len(key)], key) {
+		it := node.Key()
+		it.Next()
+		start := it.Node().Data
+		for i := parent + 1; i < len(key); i++ {
+			it.Next()
+		}
+		return danger.BytesRange(start, it.Node().Data)
+	}
+
+	if value.Kind == unstable.InlineTable {
+		it := value.Children()
+		for it.Next() {
+			if b := findKeyValue(p, kt, it.Node(), key); b != nil {
+				return b
+			}
+		}
+	}
+
+	return nil
+}
+
+func keyEqual(a []string, b Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func firstLine(b []byte) []byte {
+	if idx := bytes.IndexAny(b, "\r\n"); idx >= 0 {
+		return b[:idx]
+	}
+	return b
+}
+
 // decodeErrorFromHighlight creates a DecodeError referencing a highlighted
 // range of bytes from document.
 //
@@ -85,13 +535,25 @@ func (e *DecodeError) Key() Key {
 //
 // The function copies all bytes used in DecodeError, so that document and
 // highlight can be freely deallocated.
-//
-//nolint:funlen
 func wrapDecodeError(document []byte, de *unstable.ParserError) *DecodeError {
-	offset := danger.SubsliceOffset(document, de.Highlight)
+	return wrapDecodeErrorAt(document, 1, 0, de)
+}
 
+// wrapDecodeErrorAt is the same as wrapDecodeError, but document is a window
+// of a larger document, which starts at the given line and offset.
+func wrapDecodeErrorAt(document []byte, firstLine, firstOffset int, de *unstable.ParserError) *DecodeError {
+	return wrapDecodeErrorOffset(document, firstLine, firstOffset, danger.SubsliceOffset(document, de.Highlight), de)
+}
+
+// wrapDecodeErrorOffset is the same as wrapDecodeErrorAt, but the offset of
+// the highlight in document is given. It is needed for empty highlights, whose
+// offset cannot be recovered from the slice at the end of document.
+//
+//nolint:funlen
+func wrapDecodeErrorOffset(document []byte, firstLine, firstOffset, offset int, de *unstable.ParserError) *DecodeError {
 	errMessage := de.Error()
 	errLine, errColumn := positionAtEnd(document[:offset])
+	errLine += firstLine - 1
 	before, after := linesOfContext(document, de.Highlight, offset, 3)
 
 	var buf strings.Builder
@@ -166,6 +628,9 @@ func wrapDecodeError(document []byte, de *unstable.ParserError) *DecodeError {
 		line:    errLine,
 		column:  errColumn,
 		key:     de.Key,
+		code:    CodeSyntax,
+		start:   firstOffset + offset,
+		end:     firstOffset + offset + len(de.Highlight),
 		human:   buf.String(),
 	}
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/pelletier/go-toml/v2/unstable"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //nolint:funlen
@@ -205,6 +207,373 @@ func TestDecodeError_Accessors(t *testing.T) {
 	assert.Equal(t, "bar", e.String())
 }
 
+func TestNewDecodeError(t *testing.T) {
+	doc := []byte("[server]\nhost = 'localhost'\nport = 100\n")
+
+	e := NewDecodeError(doc, 35, 38, "port is reserved")
+	assert.Equal(t, "toml: port is reserved", e.Error())
+	r, c := e.Position()
+	assert.Equal(t, 3, r)
+	assert.Equal(t, 8, c)
+	assert.Nil(t, e.Key())
+	assert.Equal(t, CodeOther, e.Code())
+	start, end := e.Range()
+	assert.Equal(t, 35, start)
+	assert.Equal(t, 38, end)
+	assert.Equal(t, `1| [server]
+2| host = 'localhost'
+3| port = 100
+ |        ~~~ port is reserved`, e.String())
+}
+
+func TestNewDecodeErrorEndOfDocument(t *testing.T) {
+	doc := []byte("a = 1\nb = 2")
+
+	e := NewDecodeError(doc, len(doc), len(doc), "missing c")
+	r, c := e.Position()
+	assert.Equal(t, 2, r)
+	assert.Equal(t, 6, c)
+	start, end := e.Range()
+	assert.Equal(t, len(doc), start)
+	assert.Equal(t, len(doc), end)
+}
+
+func TestNewKeyDecodeError(t *testing.T) {
+	doc := []byte(`a = 1
+b = { c = [1,
+  2], d = "x" }
+
+[t.u]
+v = 2
+
+[[arr]]
+w = 3
+[[arr]]
+w = 4
+
+[x]
+y.z.w = 5
+`)
+
+	examples := []struct {
+		desc   string
+		key    Key
+		line   int
+		column int
+		human  string
+	}{
+		{
+			desc:   "top level key",
+			key:    Key{"a"},
+			line:   1,
+			column: 5,
+			human: `1| a = 1
+ |     ~ bad
+2| b = { c = [1,
+3|   2], d = "x" }
+4|`,
+		},
+		{
+			desc:   "inline table",
+			key:    Key{"b", "d"},
+			line:   3,
+			column: 11,
+		},
+		{
+			desc:   "multiline value",
+			key:    Key{"b", "c"},
+			line:   2,
+			column: 11,
+			human: `1| a = 1
+2| b = { c = [1,
+ |           ~~~ bad
+3|   2], d = "x" }
+4|
+5| [t.u]`,
+		},
+		{
+			desc:   "table",
+			key:    Key{"t", "u"},
+			line:   5,
+			column: 2,
+		},
+		{
+			desc:   "key in table",
+			key:    Key{"t", "u", "v"},
+			line:   6,
+			column: 5,
+		},
+		{
+			desc:   "first element of array table",
+			key:    Key{"arr", "w"},
+			line:   9,
+			column: 5,
+		},
+		{
+			desc:   "table defined by dotted key",
+			key:    Key{"x", "y", "z"},
+			line:   14,
+			column: 1,
+			human: `11| w = 4
+12|
+13| [x]
+14| y.z.w = 5
+  | ~~~ bad`,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			derr, err := NewKeyDecodeError(doc, e.key, "bad")
+			require.NoError(t, err)
+			assert.Equal(t, "toml: bad", derr.Error())
+			assert.Equal(t, e.key, derr.Key())
+			r, c := derr.Position()
+			assert.Equal(t, e.line, r)
+			assert.Equal(t, e.column, c)
+			if e.human != "" {
+				assert.Equal(t, e.human, derr.String())
+			}
+		})
+	}
+}
+
+func TestNewKeyDecodeErrorFailures(t *testing.T) {
+	_, err := NewKeyDecodeError([]byte("a = 1"), Key{"b"}, "bad")
+	assert.EqualError(t, err, "toml: key b not found in document")
+
+	_, err = NewKeyDecodeError([]byte("a = 1\nb = "), Key{"b"}, "bad")
+	var derr *DecodeError
+	assert.True(t, errors.As(err, &derr))
+}
+
+func TestDecodeErrorDetails(t *testing.T) {
+	type config struct {
+		Name    string
+		Servers []struct {
+			Port  uint16
+			Hosts []string
+		}
+		Limits map[int8]float32
+	}
+
+	examples := []struct {
+		desc     string
+		doc      string
+		strict   bool
+		code     ErrorCode
+		key      Key
+		found    string
+		expected reflect.Type
+		raw      string
+	}{
+		{
+			desc: "syntax",
+			doc:  "name = 'a'\n[[servers]]\nport = 1979-13-01\n",
+			code: CodeSyntax,
+			key:  Key{"servers", "port"},
+			raw:  "1979-13-01",
+		},
+		{
+			desc: "invalid document",
+			doc:  "[[servers]]\nport = \n",
+			code: CodeSyntax,
+			key:  Key{"servers"},
+			raw:  "\n",
+		},
+		{
+			desc:     "type mismatch",
+			doc:      "name = 'a'\n[[servers]]\nport = 'http'\n",
+			code:     CodeTypeMismatch,
+			key:      Key{"servers", "port"},
+			found:    "string",
+			expected: reflect.TypeOf(uint16(0)),
+			raw:      "'http'",
+		},
+		{
+			desc:     "array element",
+			doc:      "[[servers]]\nhosts = ['a', 2]\n",
+			code:     CodeTypeMismatch,
+			key:      Key{"servers", "hosts"},
+			found:    "integer",
+			expected: reflect.TypeOf(""),
+			raw:      "2",
+		},
+		{
+			desc:     "inline table",
+			doc:      "name = {first = 'a'}\n",
+			code:     CodeTypeMismatch,
+			key:      Key{"name"},
+			found:    "inline table",
+			expected: reflect.TypeOf(""),
+			raw:      "{",
+		},
+		{
+			desc:     "overflow",
+			doc:      "[[servers]]\nport = 70000\n",
+			code:     CodeOverflow,
+			key:      Key{"servers", "port"},
+			found:    "integer",
+			expected: reflect.TypeOf(uint16(0)),
+			raw:      "70000",
+		},
+		{
+			desc:     "map key overflow",
+			doc:      "limits = {1 = 1.0, 300 = 2.0}\n",
+			code:     CodeOverflow,
+			key:      Key{"limits", "300"},
+			found:    "key",
+			expected: reflect.TypeOf(int8(0)),
+			raw:      "300",
+		},
+		{
+			desc:  "duplicate key",
+			doc:   "[limits]\n1 = 1.0\n1 = [2.0]\n",
+			code:  CodeDuplicateKey,
+			key:   Key{"limits", "1"},
+			found: "array",
+			raw:   "1",
+		},
+		{
+			desc:  "duplicate table",
+			doc:   "[limits]\n[limits]\n",
+			code:  CodeDuplicateKey,
+			key:   Key{"limits"},
+			found: "table",
+			raw:   "limits",
+		},
+		{
+			desc:     "integer overflow",
+			doc:      "[[servers]]\nport = 99999999999999999999\n",
+			code:     CodeOverflow,
+			key:      Key{"servers", "port"},
+			found:    "integer",
+			expected: reflect.TypeOf(uint16(0)),
+			raw:      "99999999999999999999",
+		},
+		{
+			desc:   "unknown field",
+			doc:    "name = 'a'\n[[servers]]\nport = 80\naddress = 'x'\n",
+			strict: true,
+			code:   CodeUnknownField,
+			key:    Key{"servers", "address"},
+			found:  "string",
+			raw:    "address",
+		},
+		{
+			desc:   "unknown table",
+			doc:    "[other]\n",
+			strict: true,
+			code:   CodeUnknownField,
+			key:    Key{"other"},
+			found:  "table",
+			raw:    "other",
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		for _, streaming := range []bool{false, true} {
+			streaming := streaming
+			t.Run(fmt.Sprintf("%s/streaming=%t", e.desc, streaming), func(t *testing.T) {
+				d := NewDecoder(strings.NewReader(e.doc))
+				if e.strict {
+					d.DisallowUnknownFields()
+				}
+				if streaming {
+					d.EnableStreaming()
+				}
+
+				var c config
+				err := d.Decode(&c)
+
+				var derr *DecodeError
+				var serr *StrictMissingError
+				if errors.As(err, &serr) {
+					require.Len(t, serr.Errors, 1)
+					derr = &serr.Errors[0]
+				} else {
+					require.True(t, errors.As(err, &derr), "%v", err)
+				}
+
+				assert.Equal(t, e.code, derr.Code())
+				assert.Equal(t, e.key, derr.Key())
+				assert.Equal(t, e.found, derr.Found())
+				assert.Equal(t, e.expected, derr.Expected())
+				start, end := derr.Range()
+				assert.Equal(t, e.raw, e.doc[start:end])
+			})
+		}
+	}
+}
+
+func TestDecodeErrorDetailsCollected(t *testing.T) {
+	doc := "a = 'x'\n[t]\nb = 1000\nc = [1, 'y']\n"
+
+	var v struct {
+		A int
+		T struct {
+			B int8
+			C []int
+		}
+	}
+	err := NewDecoder(strings.NewReader(doc)).CollectErrors().Decode(&v)
+
+	var derrs *DecodeErrors
+	require.True(t, errors.As(err, &derrs), "%v", err)
+	require.Len(t, derrs.Errors, 3)
+
+	expected := []struct {
+		code ErrorCode
+		key  Key
+	}{
+		{CodeTypeMismatch, Key{"a"}},
+		{CodeOverflow, Key{"t", "b"}},
+		{CodeTypeMismatch, Key{"t", "c"}},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.code, derrs.Errors[i].Code())
+		assert.Equal(t, e.key, derrs.Errors[i].Key())
+	}
+}
+
+func TestErrorCodeString(t *testing.T) {
+	assert.Equal(t, "TypeMismatch", CodeTypeMismatch.String())
+	assert.Panics(t, func() {
+		_ = ErrorCode(-1).String()
+	})
+}
+
+func ExampleNewKeyDecodeError() {
+	doc := []byte(`
+[server]
+port = 100
+`)
+
+	var cfg struct {
+		Server struct {
+			Port int
+		}
+	}
+	if err := Unmarshal(doc, &cfg); err != nil {
+		panic(err)
+	}
+
+	if cfg.Server.Port < 1024 {
+		derr, err := NewKeyDecodeError(doc, Key{"server", "port"}, "port must be at least 1024")
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(derr.String())
+	}
+
+	// Output:
+	// 2| [server]
+	// 3| port = 100
+	//  |        ~~~ port must be at least 1024
+}
+
 func ExampleDecodeError() {
 	doc := `name = 123__456`
 
@@ -0,0 +1,156 @@
+package toml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// expandVariables replaces the ${NAME} and ${NAME:-default} references in the
+// content of a basic string node. Literal strings are left untouched, as are
+// references written with escape sequences, like \u0024{NAME}.
+func (d *decoder) expandVariables(node *unstable.Node) error {
+	raw := d.p.Raw(node.Raw)
+	if len(raw) == 0 || raw[0] != '"' || bytes.IndexByte(node.Data, '$') < 0 {
+		return nil
+	}
+
+	b, err := expandString(node.Data, escapedBytes(raw, len(node.Data)), d.lookupVariable)
+	if err != nil {
+		return withErrorDetails(unstable.NewParserError(raw, "%s", err), CodeOther, "", nil)
+	}
+	node.Data = b
+
+	return nil
+}
+
+// escapedBytes reports, for each byte of the content of the basic string raw,
+// whether it results from an escape sequence. It returns nil if raw does not
+// contain any escape sequence.
+func escapedBytes(raw []byte, size int) []bool {
+	if bytes.IndexByte(raw, '\\') < 0 {
+		return nil
+	}
+
+	var body []byte
+	if bytes.HasPrefix(raw, []byte(`"""`)) {
+		body = raw[3 : len(raw)-3]
+		// The new line following the opening delimiter is trimmed.
+		if bytes.HasPrefix(body, []byte("\n")) {
+			body = body[1:]
+		} else if bytes.HasPrefix(body, []byte("\r\n")) {
+			body = body[2:]
+		}
+	} else {
+		body = raw[1 : len(raw)-1]
+	}
+
+	escaped := make([]bool, 0, size)
+	for i := 0; i < len(body); {
+		if body[i] != '\\' {
+			escaped = append(escaped, false)
+			i++
+			continue
+		}
+
+		switch c := body[i+1]; c {
+		case 'x', 'u', 'U':
+			n := 2
+			if c == 'u' {
+				n = 4
+			} else if c == 'U' {
+				n = 8
+			}
+			// The parser already checked that the code point is valid.
+			r, _ := strconv.ParseUint(string(body[i+2:i+2+n]), 16, 32)
+			for k := utf8.RuneLen(rune(r)); k > 0; k-- {
+				escaped = append(escaped, true)
+			}
+			i += 2 + n
+		case ' ', '\t', '\r', '\n':
+			// A line ending backslash is trimmed along with the whitespace
+			// that follows it.
+			i++
+			for i < len(body) && (body[i] == ' ' || body[i] == '\t' || body[i] == '\r' || body[i] == '\n') {
+				i++
+			}
+		default:
+			escaped = append(escaped, true)
+			i += 2
+		}
+	}
+
+	return escaped
+}
+
+// expandString returns a copy of s where variable references are replaced
+// using lookup. $${ is an escaped ${. Bytes of s marked in escaped do not
+// start references; escaped may be nil if there are none.
+func expandString(s []byte, escaped []bool, lookup func(string) (string, bool)) ([]byte, error) {
+	literal := func(i, n int) bool {
+		if escaped == nil {
+			return true
+		}
+		for _, e := range escaped[i : i+n] {
+			if e {
+				return false
+			}
+		}
+		return true
+	}
+
+	b := make([]byte, 0, len(s))
+
+	for i := 0; i < len(s); {
+		j := bytes.IndexByte(s[i:], '$')
+		if j < 0 {
+			b = append(b, s[i:]...)
+			break
+		}
+		b = append(b, s[i:i+j]...)
+		i += j
+		rest := s[i:]
+
+		if bytes.HasPrefix(rest, []byte("$${")) && literal(i, 3) {
+			b = append(b, "${"...)
+			i += 3
+			continue
+		}
+		if !bytes.HasPrefix(rest, []byte("${")) || !literal(i, 2) {
+			b = append(b, '$')
+			i++
+			continue
+		}
+
+		end := bytes.IndexByte(rest, '}')
+		if end < 0 {
+			return nil, errors.New("unterminated variable reference")
+		}
+		ref := string(rest[2:end])
+		i += end + 1
+
+		name, def, hasDefault := ref, "", false
+		if i := strings.Index(ref, ":-"); i >= 0 {
+			name, def, hasDefault = ref[:i], ref[i+2:], true
+		}
+		if name == "" {
+			return nil, fmt.Errorf("empty variable name in ${%s}", ref)
+		}
+
+		value, ok := lookup(name)
+		if !ok || (hasDefault && value == "") {
+			if !hasDefault {
+				return nil, fmt.Errorf("variable %s is not defined", name)
+			}
+			value = def
+		}
+		b = append(b, value...)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,147 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderExpandVariables(t *testing.T) {
+	vars := map[string]string{
+		"USER":  "admin",
+		"EMPTY": "",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	examples := []struct {
+		desc     string
+		input    string
+		expected string
+	}{
+		{desc: "no reference", input: `"a $ b"`, expected: "a $ b"},
+		{desc: "variable", input: `"${USER}"`, expected: "admin"},
+		{desc: "inside text", input: `"u=${USER}; p=${USER}"`, expected: "u=admin; p=admin"},
+		{desc: "default", input: `"${HOST:-localhost}"`, expected: "localhost"},
+		{desc: "empty default", input: `"${HOST:-}"`, expected: ""},
+		{desc: "default unused", input: `"${USER:-x}"`, expected: "admin"},
+		{desc: "empty variable", input: `"${EMPTY}"`, expected: ""},
+		{desc: "empty variable with default", input: `"${EMPTY:-x}"`, expected: "x"},
+		{desc: "escaped", input: `"$${USER}"`, expected: "${USER}"},
+		{desc: "multiline", input: `"""
+${USER}"""`, expected: "admin"},
+		{desc: "literal string", input: `'${USER}'`, expected: "${USER}"},
+		{desc: "escaped dollar", input: `"\u0024{USER}"`, expected: "${USER}"},
+		{desc: "escaped brace", input: `"$\u007BUSER}"`, expected: "${USER}"},
+		{desc: "after escapes", input: `"\t\u00e9\\${USER}"`, expected: "\té\\admin"},
+		{desc: "multiline after escapes", input: "\"\"\"\r\n\\\n  \\U0001F600 ${USER} \\u0024{USER}\"\"\"", expected: "\U0001F600 admin ${USER}"},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var v struct{ A string }
+			err := toml.NewDecoder(strings.NewReader("a = " + e.input)).ExpandVariables(lookup).Decode(&v)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, v.A)
+		})
+	}
+}
+
+func TestDecoderExpandVariablesNested(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		return strings.ToLower(name), true
+	}
+
+	doc := `
+a = ["${X}", { b = "${Y}" }]
+"${Z}" = "${Z}"
+`
+	var v map[string]interface{}
+	err := toml.NewDecoder(strings.NewReader(doc)).ExpandVariables(lookup).Decode(&v)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a":    []interface{}{"x", map[string]interface{}{"b": "y"}},
+		"${Z}": "z",
+	}, v)
+}
+
+func TestDecoderExpandVariablesErrors(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		return "", false
+	}
+
+	examples := []struct {
+		desc   string
+		input  string
+		err    string
+		column int
+	}{
+		{desc: "undefined", input: `a = 1
+b = "x ${NOPE}"`, err: "toml: variable NOPE is not defined", column: 5},
+		{desc: "unterminated", input: `b = "${NOPE"`, err: "toml: unterminated variable reference", column: 5},
+		{desc: "empty name", input: `b = "${:-x}"`, err: "toml: empty variable name in ${:-x}", column: 5},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var v map[string]interface{}
+			err := toml.NewDecoder(strings.NewReader(e.input)).ExpandVariables(lookup).Decode(&v)
+
+			var derr *toml.DecodeError
+			require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+			assert.Equal(t, e.err, derr.Error())
+			_, column := derr.Position()
+			assert.Equal(t, e.column, column)
+		})
+	}
+}
+
+func TestDecoderExpandVariablesEnv(t *testing.T) {
+	require.NoError(t, os.Setenv("GO_TOML_TEST_VAR", "value"))
+	defer os.Unsetenv("GO_TOML_TEST_VAR")
+
+	var v struct{ A string }
+	err := toml.NewDecoder(strings.NewReader(`a = "${GO_TOML_TEST_VAR}"`)).ExpandVariables(nil).Decode(&v)
+	require.NoError(t, err)
+	assert.Equal(t, "value", v.A)
+}
+
+func ExampleDecoder_ExpandVariables() {
+	doc := `
+[database]
+user = "${DB_USER:-admin}"
+password = "${DB_PASSWORD}"
+`
+
+	secrets := map[string]string{"DB_PASSWORD": "hunter2"}
+	lookup := func(name string) (string, bool) {
+		v, ok := secrets[name]
+		return v, ok
+	}
+
+	var cfg struct {
+		Database struct {
+			User     string
+			Password string
+		}
+	}
+	err := toml.NewDecoder(strings.NewReader(doc)).ExpandVariables(lookup).Decode(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(cfg.Database.User, cfg.Database.Password)
+
+	// Output:
+	// admin hunter2
+}
@@ -0,0 +1,376 @@
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pelletier/go-toml/v2/internal/danger"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// documentKeys is a tree of the keys defined by a document. Once the document
+// has been decoded, it is used to find the struct fields that are missing from
+// it.
+type documentKeys struct {
+	children map[string]*documentKeys
+
+	// Elements of an array table or of an array.
+	elems []*documentKeys
+}
+
+func (k *documentKeys) child(name []byte) *documentKeys {
+	c, ok := k.children[string(name)]
+	if !ok {
+		if k.children == nil {
+			k.children = map[string]*documentKeys{}
+		}
+		c = &documentKeys{}
+		k.children[string(name)] = c
+	}
+	return c
+}
+
+// descend returns the node of the table named by key, going into the last
+// element of the array tables along the way.
+func (k *documentKeys) descend(key unstable.Iterator) *documentKeys {
+	for key.Next() {
+		k = k.child(key.Node().Data)
+		if len(k.elems) > 0 {
+			k = k.elems[len(k.elems)-1]
+		}
+	}
+	return k
+}
+
+// setValue records the keys contained in the value of a key-value.
+func (k *documentKeys) setValue(value *unstable.Node) {
+	switch value.Kind {
+	case unstable.InlineTable:
+		it := value.Children()
+		for it.Next() {
+			kv := it.Node()
+			c := k
+			key := kv.Key()
+			for key.Next() {
+				c = c.child(key.Node().Data)
+			}
+			c.setValue(kv.Value())
+		}
+	case unstable.Array:
+		it := value.Children()
+		for it.Next() {
+			e := &documentKeys{}
+			e.setValue(it.Node())
+			k.elems = append(k.elems, e)
+		}
+	}
+}
+
+// fieldTags keeps track of the keys of the document when the target contains
+// struct fields with a default tag or a required option. Once the document has
+// been decoded, the fields missing from it are set to their default value or
+// reported as missing.
+type fieldTags struct {
+	Enabled bool
+
+	// Node of the document table headers are relative to, and node of the
+	// current table.
+	root  *documentKeys
+	table *documentKeys
+
+	// Required fields missing from the document.
+	missing []MissingField
+}
+
+// Table returns the node of the current table.
+func (s *fieldTags) Table() *documentKeys {
+	if s.root == nil {
+		s.root = &documentKeys{}
+	}
+	if s.table == nil {
+		s.table = s.root
+	}
+	return s.table
+}
+
+func (s *fieldTags) Record(expr *unstable.Node) {
+	if !s.Enabled {
+		return
+	}
+	s.Table()
+
+	switch expr.Kind {
+	case unstable.KeyValue:
+		c := s.table
+		key := expr.Key()
+		for key.Next() {
+			c = c.child(key.Node().Data)
+		}
+		c.setValue(expr.Value())
+	case unstable.Table:
+		s.table = s.root.descend(expr.Key())
+	case unstable.ArrayTable:
+		c := s.root
+		key := expr.Key()
+		for key.Next() {
+			c = c.child(key.Node().Data)
+			if key.IsLast() {
+				e := &documentKeys{}
+				c.elems = append(c.elems, e)
+				c = e
+			} else if len(c.elems) > 0 {
+				c = c.elems[len(c.elems)-1]
+			}
+		}
+		s.table = c
+	}
+}
+
+// Error returns a RequiredMissingError if required fields are missing from
+// the document.
+func (s *fieldTags) Error() error {
+	if len(s.missing) == 0 {
+		return nil
+	}
+	return &RequiredMissingError{Fields: s.missing}
+}
+
+// checkFields walks v to set the struct fields that are missing from the
+// document to their default value, and to record the required ones. keys is
+// nil when v is not present in the document at all. field and key are the
+// path of v, in Go and in the document.
+func (d *decoder) checkFields(v reflect.Value, keys *documentKeys, field string, key Key) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return d.checkFields(v.Elem(), keys, field, key)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			var elem *documentKeys
+			if keys != nil && i < len(keys.elems) {
+				elem = keys.elems[i]
+			}
+			err := d.checkFields(v.Index(i), elem, fmt.Sprintf("%s[%d]", field, i), key)
+			if err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if !typeHasFieldTags(v.Type().Elem()) {
+			return nil
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			var elem *documentKeys
+			if keys != nil {
+				if k, ok := documentKey(iter.Key()); ok {
+					elem = keys.children[k]
+				}
+			}
+			// Map values are not addressable.
+			mv := reflect.New(v.Type().Elem()).Elem()
+			mv.Set(iter.Value())
+			mk := fmt.Sprint(iter.Key().Interface())
+			err := d.checkFields(mv, elem, fmt.Sprintf("%s[%q]", field, mk), appendKey(key, mk))
+			if err != nil {
+				return err
+			}
+			v.SetMapIndex(iter.Key(), mv)
+		}
+	case reflect.Struct:
+		return d.checkStructFields(v, keys, field, key)
+	}
+	return nil
+}
+
+// documentKey returns the key of the document decoded into the map key k.
+func documentKey(k reflect.Value) (string, bool) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), true
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool()), true
+	default:
+		return "", false
+	}
+}
+
+func (d *decoder) checkStructFields(v reflect.Value, keys *documentKeys, field string, key Key) error {
+	t := v.Type()
+
+	// Find the fields the keys of the document were decoded into, the same
+	// way the decoder does.
+	var present map[string]*documentKeys
+	if keys != nil {
+		present = make(map[string]*documentKeys, len(keys.children))
+		for name, c := range keys.children {
+			path, found := structFieldPath(v, name)
+			if found {
+				present[fmt.Sprint(path)] = c
+			}
+		}
+	}
+
+	var err error
+	forEachField(t, nil, func(name string, path []int) {
+		if err != nil {
+			return
+		}
+
+		sf := t.FieldByIndex(path)
+		fieldName := sf.Name
+		if field != "" {
+			fieldName = field + "." + sf.Name
+		}
+		fieldKey := appendKey(key, name)
+
+		c, ok := present[fmt.Sprint(path)]
+		if !ok {
+			_, opts := parseTag(sf.Tag.Get("toml"))
+			if opts.required {
+				d.fieldTags.missing = append(d.fieldTags.missing, MissingField{
+					Field: fieldName,
+					Key:   fieldKey,
+				})
+			}
+
+			text, hasDefault := sf.Tag.Lookup("default")
+			if hasDefault {
+				f := fieldByIndex(v, path)
+				if f.IsZero() {
+					err = d.decodeDefault(text, f)
+					if err != nil {
+						err = fmt.Errorf("toml: invalid default value for struct field %s.%s: %w", t, sf.Name, err)
+						return
+					}
+				}
+			}
+		}
+
+		if !typeHasFieldTags(sf.Type) {
+			return
+		}
+		f, exists := existingField(v, path)
+		if exists {
+			err = d.checkFields(f, c, fieldName, fieldKey)
+		}
+	})
+	return err
+}
+
+// appendKey returns a copy of key with name added at the end.
+func appendKey(key Key, name string) Key {
+	k := make(Key, len(key), len(key)+1)
+	copy(k, key)
+	return append(k, name)
+}
+
+// decodeDefault decodes the content of a default tag, written as a TOML value,
+// into v.
+func (d *decoder) decodeDefault(text string, v reflect.Value) error {
+	p := unstable.Parser{SpecVersion: d.p.SpecVersion}
+	p.Reset([]byte("v = " + text))
+
+	if !p.NextExpression() {
+		if p.Error() != nil {
+			return defaultError(p.Error())
+		}
+		return errors.New("missing value")
+	}
+	expr := p.Expression()
+	if p.NextExpression() || p.Error() != nil {
+		return errors.New("expected a single value")
+	}
+
+	// Default values are not part of the document, and their errors are
+	// reported for the field.
+	dd := d.nested(&p)
+	dd.strict = strict{}
+	dd.collectErrors = false
+	return defaultError(dd.handleValue(expr.Value(), v))
+}
+
+// defaultError strips the prefix of errors returned by the decoder, as they
+// are wrapped in a more descriptive error.
+func defaultError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var perr *unstable.ParserError
+	if errors.As(err, &perr) {
+		return perr
+	}
+	return errors.New(strings.TrimPrefix(err.Error(), "toml: "))
+}
+
+// existingField is the same as reflect.Value.FieldByIndex, but returns false
+// instead of going through a nil pointer.
+func existingField(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, x := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+var globalFieldTagsCache atomic.Value // map[danger.TypeID]bool
+
+// typeHasFieldTags returns true if values of type t may contain struct fields
+// with a default tag or a required option.
+func typeHasFieldTags(t reflect.Type) bool {
+	cache, _ := globalFieldTagsCache.Load().(map[danger.TypeID]bool)
+	has, ok := cache[danger.MakeTypeID(t)]
+	if ok {
+		return has
+	}
+
+	has = hasFieldTags(t, map[reflect.Type]bool{})
+
+	newCache := make(map[danger.TypeID]bool, len(cache)+1)
+	newCache[danger.MakeTypeID(t)] = has
+	for k, v := range cache {
+		newCache[k] = v
+	}
+	globalFieldTagsCache.Store(newCache)
+
+	return has
+}
+
+func hasFieldTags(t reflect.Type, visited map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hasFieldTags(t.Elem(), visited)
+	case reflect.Struct:
+		if visited[t] {
+			return false
+		}
+		visited[t] = true
+
+		found := false
+		forEachField(t, nil, func(name string, path []int) {
+			f := t.FieldByIndex(path)
+			_, hasDefault := f.Tag.Lookup("default")
+			_, opts := parseTag(f.Tag.Get("toml"))
+			if hasDefault || opts.required || hasFieldTags(f.Type, visited) {
+				found = true
+			}
+		})
+		return found
+	}
+	return false
+}
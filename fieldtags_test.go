@@ -0,0 +1,284 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderDefaults(t *testing.T) {
+	type item struct {
+		ID   int
+		Tags []string `default:"['a', 'b']"`
+	}
+
+	type server struct {
+		Host  string `default:"'localhost'"`
+		Ports []int  `default:"[80, 443]"`
+	}
+
+	type config struct {
+		Name    string         `default:"'x'"`
+		Enabled bool           `default:"true"`
+		Ratio   float64        `default:"0.5"`
+		Since   time.Time      `default:"1979-05-27"`
+		Date    toml.LocalDate `default:"1979-05-27"`
+		Limits  map[string]int `default:"{ max = 10 }"`
+		Server  server
+		Backup  *server
+		Items   []item
+		Extra   map[string]server
+	}
+
+	doc := `
+name = ""
+enabled = false
+
+[server]
+ports = [8080]
+
+[[items]]
+id = 1
+[[items]]
+id = 2
+tags = []
+
+[extra.a]
+host = "a"
+[extra.b]
+`
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			var cfg config
+			d := toml.NewDecoder(strings.NewReader(doc))
+			if streaming {
+				d.EnableStreaming()
+			}
+			require.NoError(t, d.Decode(&cfg))
+
+			expected := config{
+				Ratio:  0.5,
+				Since:  time.Date(1979, 5, 27, 0, 0, 0, 0, time.Local),
+				Date:   toml.LocalDate{Year: 1979, Month: 5, Day: 27},
+				Limits: map[string]int{"max": 10},
+				Server: server{Host: "localhost", Ports: []int{8080}},
+				Items: []item{
+					{ID: 1, Tags: []string{"a", "b"}},
+					{ID: 2, Tags: []string{}},
+				},
+				Extra: map[string]server{
+					"a": {Host: "a", Ports: []int{80, 443}},
+					"b": {Host: "localhost", Ports: []int{80, 443}},
+				},
+			}
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestDecoderDefaultsExistingValues(t *testing.T) {
+	type inner struct {
+		A int `default:"1"`
+		B int `default:"2"`
+	}
+	type config struct {
+		Inner   *inner
+		Missing *inner
+		Inline  []inner
+	}
+
+	cfg := config{Inner: &inner{B: 3}}
+	err := toml.Unmarshal([]byte("inline = [{}, { a = 4 }]"), &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, config{
+		Inner:  &inner{A: 1, B: 3},
+		Inline: []inner{{A: 1, B: 2}, {A: 4, B: 2}},
+	}, cfg)
+}
+
+func TestDecoderDefaultsEmbedded(t *testing.T) {
+	type Base struct {
+		Level string `default:"'info'"`
+	}
+	type config struct {
+		*Base
+		Name string `toml:"name" default:"'x'"`
+	}
+
+	var cfg config
+	require.NoError(t, toml.Unmarshal([]byte(`Name = "y"`), &cfg))
+	assert.Equal(t, "y", cfg.Name)
+	require.NotNil(t, cfg.Base)
+	assert.Equal(t, "info", cfg.Level)
+}
+
+func TestDecoderDefaultsInvalid(t *testing.T) {
+	examples := []struct {
+		desc string
+		v    interface{}
+		err  string
+	}{
+		{
+			desc: "syntax",
+			v: &struct {
+				A int `default:"1 2"`
+			}{},
+			err: "toml: invalid default value for struct field struct { A int \"default:\\\"1 2\\\"\" }.A: expected a single value",
+		},
+		{
+			desc: "type",
+			v: &struct {
+				A int `default:"'x'"`
+			}{},
+			err: "cannot decode TOML string into a Go value of type int",
+		},
+		{
+			desc: "empty",
+			v: &struct {
+				A int `default:""`
+			}{},
+			err: "struct field",
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			err := toml.Unmarshal([]byte(""), e.v)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), e.err)
+		})
+	}
+}
+
+func ExampleDecoder_Decode_defaults() {
+	doc := `
+[server]
+host = "example.com"
+`
+
+	var cfg struct {
+		Server struct {
+			Host string `default:"'localhost'"`
+			Port int    `default:"8080"`
+		}
+	}
+
+	err := toml.Unmarshal([]byte(doc), &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(cfg.Server.Host, cfg.Server.Port)
+
+	// Output:
+	// example.com 8080
+}
+
+func TestDecoderRequired(t *testing.T) {
+	type item struct {
+		ID   int    `toml:"id,required"`
+		Name string `toml:"name"`
+	}
+
+	type config struct {
+		Name   string `toml:",required"`
+		Port   int    `toml:"port,required" default:"80"`
+		Server struct {
+			Host string `toml:"host,required"`
+		} `toml:"server"`
+		Backup *struct {
+			Host string `toml:"host,required"`
+		} `toml:"backup"`
+		Items []item          `toml:"items"`
+		Extra map[string]item `toml:"extra"`
+	}
+
+	doc := `
+name = "x"
+
+[[items]]
+id = 1
+[[items]]
+name = "b"
+
+[extra.a]
+name = "a"
+`
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			var cfg config
+			d := toml.NewDecoder(strings.NewReader(doc))
+			if streaming {
+				d.EnableStreaming()
+			}
+			err := d.Decode(&cfg)
+
+			var rerr *toml.RequiredMissingError
+			require.True(t, errors.As(err, &rerr), "%T: %s", err, err)
+			assert.Equal(t, []toml.MissingField{
+				{Field: "Port", Key: toml.Key{"port"}},
+				{Field: "Server.Host", Key: toml.Key{"server", "host"}},
+				{Field: "Items[1].ID", Key: toml.Key{"items", "id"}},
+				{Field: `Extra["a"].ID`, Key: toml.Key{"extra", "a", "id"}},
+			}, rerr.Fields)
+			assert.Equal(t, "toml: 4 required fields are missing from the document", err.Error())
+			assert.Equal(t, `Port (port)
+Server.Host (server.host)
+Items[1].ID (items.id)
+Extra["a"].ID (extra.a.id)`, rerr.String())
+
+			// The document is still decoded, with default values.
+			assert.Equal(t, "x", cfg.Name)
+			assert.Equal(t, 80, cfg.Port)
+			assert.Len(t, cfg.Items, 2)
+		})
+	}
+}
+
+func TestDecoderRequiredPresent(t *testing.T) {
+	var cfg struct {
+		A int `toml:"a,required"`
+		B struct {
+			C int `toml:",required"`
+		}
+	}
+	require.NoError(t, toml.Unmarshal([]byte("a = 0\nb.C = 0"), &cfg))
+
+	err := toml.Unmarshal([]byte("A = 0"), &cfg)
+	require.Error(t, err)
+	assert.Equal(t, "toml: required field B.C is missing from the document", err.Error())
+}
+
+func TestDecoderRequiredStrict(t *testing.T) {
+	var cfg struct {
+		A int `toml:"a,required"`
+	}
+
+	err := toml.NewDecoder(strings.NewReader("b = 1")).DisallowUnknownFields().Decode(&cfg)
+	var serr *toml.StrictMissingError
+	require.True(t, errors.As(err, &serr), "%T: %s", err, err)
+	require.Len(t, serr.Errors, 1)
+	var rerr *toml.RequiredMissingError
+	require.True(t, errors.As(err, &rerr), "%T: %s", err, err)
+	assert.Equal(t, []toml.MissingField{{Field: "A", Key: toml.Key{"a"}}}, rerr.Fields)
+	assert.Equal(t, "toml: strict mode: fields in the document are missing in the target struct; required field A is missing from the document", err.Error())
+
+	err = toml.NewDecoder(strings.NewReader("b = 'x'\nc = 1")).CollectErrors().Decode(&struct {
+		A int `toml:"a,required"`
+		B int `toml:"b"`
+	}{})
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs), "%T: %s", err, err)
+	require.Len(t, derrs.Errors, 1)
+	require.NotNil(t, derrs.Required)
+	assert.Equal(t, []toml.MissingField{{Field: "A", Key: toml.Key{"a"}}}, derrs.Required.Fields)
+}
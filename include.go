@@ -0,0 +1,152 @@
+package toml
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// includes holds the state shared by a document and the documents it
+// includes. See Decoder.EnableIncludes.
+type includes struct {
+	fsys fs.FS
+	key  string
+
+	// Names of the documents being decoded, from the outermost one.
+	stack []string
+}
+
+// IsDirective returns true if expr is an include directive.
+func (in *includes) IsDirective(expr *unstable.Node) bool {
+	if in == nil || expr.Kind != unstable.KeyValue {
+		return false
+	}
+
+	key := expr.Key()
+	return key.Next() && key.IsLast() && string(key.Node().Data) == in.key
+}
+
+// handleInclude decodes the documents referenced by the include directive expr
+// into v, the table containing the directive.
+func (d *decoder) handleInclude(expr *unstable.Node, v reflect.Value) (reflect.Value, error) {
+	value := expr.Value()
+
+	var paths []string
+	switch value.Kind {
+	case unstable.String:
+		paths = append(paths, string(value.Data))
+	case unstable.Array:
+		it := value.Children()
+		for it.Next() {
+			n := it.Node()
+			if n.Kind != unstable.String {
+				return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(n.Extent()), "include paths must be strings, not %s", n.Kind), CodeOther, tomlKind(n.Kind), nil)
+			}
+			paths = append(paths, string(n.Data))
+		}
+	default:
+		return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(value.Extent()), "include directive must be a string or an array of strings, not %s", value.Kind), CodeOther, tomlKind(value.Kind), nil)
+	}
+
+	// The decoder sets values through the root it is given.
+	root := reflect.New(v.Type()).Elem()
+	root.Set(v)
+
+	for _, p := range paths {
+		err := d.includeDocument(resolveInclude(d.p.Origin, p), root, d.p.Raw(value.Raw))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+
+	return root, nil
+}
+
+// resolveInclude returns the path in the file system of the document p
+// included from the document named from.
+func resolveInclude(from, p string) string {
+	if path.IsAbs(p) {
+		return strings.TrimPrefix(path.Clean(p), "/")
+	}
+	return path.Join(path.Dir(from), p)
+}
+
+// includeDocument decodes the document name into v. highlight is the part of
+// the including document reported in errors that prevent the document from
+// being decoded at all.
+func (d *decoder) includeDocument(name string, v reflect.Value, highlight []byte) error {
+	in := d.includes
+	if len(in.stack) == 0 {
+		in.stack = append(in.stack, d.p.Origin)
+	}
+
+	for i, n := range in.stack {
+		if n == name {
+			cycle := append(in.stack[i:len(in.stack):len(in.stack)], name)
+			return withErrorDetails(unstable.NewParserError(highlight, "include cycle: %s", strings.Join(cycle, " -> ")), CodeOther, "", nil)
+		}
+	}
+
+	b, err := fs.ReadFile(in.fsys, name)
+	if err != nil {
+		return withErrorDetails(unstable.NewParserError(highlight, "cannot include %s: %s", name, unwrapPathError(err)), CodeOther, "", nil)
+	}
+
+	in.stack = append(in.stack, name)
+	defer func() {
+		in.stack = in.stack[:len(in.stack)-1]
+	}()
+
+	p := unstable.Parser{SpecVersion: d.p.SpecVersion, Origin: name}
+	p.Reset(b)
+
+	// The keys of the included document are checked along with the ones of
+	// the including document, relative to the table containing the directive.
+	// The included document does not outlive the decoding.
+	d.seen.CopyNames = true
+	scope := d.seen.EnterScope()
+	defer func() {
+		d.seen.ExitScope(scope)
+	}()
+
+	sub := d.nested(&p)
+	sub.seen = d.seen
+	sub.strict.Source = name
+	// Metadata only describes the including document, as positions do not
+	// identify the document they are in.
+	sub.strict.Metadata = nil
+	sub.fieldTags.root = d.fieldTags.Table()
+	sub.strict.key.SetRoot(d.strict.key.Key())
+	sub.tableKey.SetRoot(d.tableKey.Key())
+
+	err = sub.fromParser(v)
+	d.seen = sub.seen
+	d.mapKeys = sub.mapKeys
+	if err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) {
+			return sub.wrapError(err, perr)
+		}
+		return err
+	}
+
+	sub.strict.Flush(b, 1, 0)
+	d.strict.errors = append(d.strict.errors, sub.strict.errors...)
+	d.errors = append(d.errors, sub.errors...)
+
+	return nil
+}
+
+// unwrapPathError removes the operation and path from err, as they are
+// already part of the error message.
+func unwrapPathError(err error) error {
+	var perr *fs.PathError
+	if errors.As(err, &perr) {
+		return perr.Err
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderIncludes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"conf/common.toml": {Data: []byte(`
+name = "common"
+[server]
+host = "localhost"
+`)},
+		"conf/db/database.toml": {Data: []byte(`
+include = "../../shared/pool.toml"
+user = "admin"
+`)},
+		"shared/pool.toml": {Data: []byte(`
+pool = 10
+`)},
+	}
+
+	doc := `
+include = ["common.toml"]
+level = 2
+
+[database]
+include = "db/database.toml"
+port = 5432
+`
+
+	type config struct {
+		Name     string
+		Level    int
+		Server   struct{ Host string }
+		Database struct {
+			User string
+			Pool int
+			Port int
+		}
+	}
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			var cfg config
+			d := toml.NewDecoder(strings.NewReader(doc)).
+				SetDocumentName("conf/main.toml").
+				EnableIncludes(fsys, "include")
+			if streaming {
+				d.EnableStreaming()
+			}
+			require.NoError(t, d.Decode(&cfg))
+
+			expected := config{Name: "common", Level: 2}
+			expected.Server.Host = "localhost"
+			expected.Database.User = "admin"
+			expected.Database.Pool = 10
+			expected.Database.Port = 5432
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
+
+func TestDecoderIncludesMap(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.toml": {Data: []byte("a = 1\n[t]\nb = 2")},
+	}
+
+	var v map[string]interface{}
+	err := toml.NewDecoder(strings.NewReader("[x]\ninclude = '/a.toml'\nc = 3")).
+		EnableIncludes(fsys, "include").
+		Decode(&v)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"x": map[string]interface{}{
+			"a": int64(1),
+			"c": int64(3),
+			"t": map[string]interface{}{"b": int64(2)},
+		},
+	}, v)
+}
+
+func TestDecoderIncludesDisabled(t *testing.T) {
+	var v map[string]interface{}
+	require.NoError(t, toml.Unmarshal([]byte("include = 'a.toml'"), &v))
+	assert.Equal(t, map[string]interface{}{"include": "a.toml"}, v)
+}
+
+func TestDecoderIncludesFieldTags(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.toml": {Data: []byte("[server]\nport = 0")},
+	}
+
+	var cfg struct {
+		Server struct {
+			Port int `toml:",required" default:"80"`
+		}
+	}
+	err := toml.NewDecoder(strings.NewReader("include = 'a.toml'")).
+		EnableIncludes(fsys, "include").
+		Decode(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Server.Port)
+}
+
+func TestDecoderIncludesErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"loop1.toml": {Data: []byte("include = 'loop2.toml'")},
+		"loop2.toml": {Data: []byte("a = 1\ninclude = 'loop1.toml'")},
+		"bad.toml":   {Data: []byte("a = 1\nb = \n")},
+		"type.toml":  {Data: []byte("a = 'x'")},
+		"ok.toml":    {Data: []byte("a = 1")},
+	}
+
+	examples := []struct {
+		desc   string
+		doc    string
+		source string
+		err    string
+		line   int
+	}{
+		{
+			desc:   "cycle",
+			doc:    "include = 'loop1.toml'",
+			source: "loop2.toml",
+			err:    "toml: loop2.toml: include cycle: loop1.toml -> loop2.toml -> loop1.toml",
+			line:   2,
+		},
+		{
+			desc:   "self",
+			doc:    "x = 0\ninclude = 'main.toml'",
+			source: "main.toml",
+			err:    "toml: main.toml: include cycle: main.toml -> main.toml",
+			line:   2,
+		},
+		{
+			desc:   "missing file",
+			doc:    "include = ['ok.toml', 'nope.toml']",
+			source: "main.toml",
+			err:    "toml: main.toml: cannot include nope.toml: file does not exist",
+			line:   1,
+		},
+		{
+			desc:   "syntax",
+			doc:    "include = 'bad.toml'",
+			source: "bad.toml",
+			err:    "toml: bad.toml: incomplete number",
+			line:   2,
+		},
+		{
+			desc:   "type",
+			doc:    "\ninclude = 'type.toml'",
+			source: "type.toml",
+			err:    "toml: type.toml: cannot decode TOML string into struct field struct { A int }.A of type int",
+			line:   1,
+		},
+		{
+			desc:   "key defined before include",
+			doc:    "a = 1\ninclude = 'ok.toml'",
+			source: "ok.toml",
+			err:    "toml: ok.toml: key a is already defined",
+			line:   1,
+		},
+		{
+			desc:   "key defined after include",
+			doc:    "include = 'ok.toml'\na = 2",
+			source: "main.toml",
+			err:    "toml: main.toml: key a is already defined",
+			line:   2,
+		},
+		{
+			desc:   "value type",
+			doc:    "include = 1",
+			source: "main.toml",
+			err:    "toml: main.toml: include directive must be a string or an array of strings, not Integer",
+			line:   1,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var v struct{ A int }
+			err := toml.NewDecoder(strings.NewReader(e.doc)).
+				SetDocumentName("main.toml").
+				EnableIncludes(fsys, "include").
+				Decode(&v)
+
+			var derr *toml.DecodeError
+			require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+			assert.Equal(t, e.err, derr.Error())
+			assert.Equal(t, e.source, derr.Source())
+			line, _ := derr.Position()
+			assert.Equal(t, e.line, line)
+		})
+	}
+}
+
+func TestDecoderIncludesTables(t *testing.T) {
+	fsys := fstest.MapFS{
+		"u.toml": {Data: []byte("[u]\nb = 1")},
+	}
+
+	var v map[string]interface{}
+	err := toml.NewDecoder(strings.NewReader("[t]\ninclude = 'u.toml'\n[u]\nc = 2")).
+		EnableIncludes(fsys, "include").
+		Decode(&v)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"t": map[string]interface{}{"u": map[string]interface{}{"b": int64(1)}},
+		"u": map[string]interface{}{"c": int64(2)},
+	}, v)
+
+	err = toml.NewDecoder(strings.NewReader("[t]\ninclude = 'u.toml'\n[t.u]\nc = 2")).
+		SetDocumentName("main.toml").
+		EnableIncludes(fsys, "include").
+		Decode(&v)
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+	assert.Equal(t, "toml: main.toml: table u already exists", derr.Error())
+	assert.Equal(t, toml.CodeDuplicateKey, derr.Code())
+}
+
+func TestDecoderIncludesStrict(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.toml": {Data: []byte("a = 1\nb = 2")},
+	}
+
+	var v struct{ A int }
+	err := toml.NewDecoder(strings.NewReader("include = 'a.toml'\nc = 3")).
+		SetDocumentName("main.toml").
+		EnableIncludes(fsys, "include").
+		DisallowUnknownFields().
+		Decode(&v)
+
+	var serr *toml.StrictMissingError
+	require.True(t, errors.As(err, &serr), "%T: %s", err, err)
+	require.Len(t, serr.Errors, 2)
+	assert.Equal(t, "a.toml", serr.Errors[0].Source())
+	assert.Equal(t, toml.Key{"b"}, serr.Errors[0].Key())
+	assert.Equal(t, "main.toml", serr.Errors[1].Source())
+	assert.Equal(t, toml.Key{"c"}, serr.Errors[1].Key())
+
+	// Keys of included documents are reported from the including table.
+	var w struct{ T struct{ A int } }
+	err = toml.NewDecoder(strings.NewReader("[t]\ninclude = 'a.toml'")).
+		SetDocumentName("main.toml").
+		EnableIncludes(fsys, "include").
+		DisallowUnknownFields().
+		Decode(&w)
+	require.True(t, errors.As(err, &serr), "%T: %s", err, err)
+	require.Len(t, serr.Errors, 1)
+	assert.Equal(t, "a.toml", serr.Errors[0].Source())
+	assert.Equal(t, toml.Key{"t", "b"}, serr.Errors[0].Key())
+}
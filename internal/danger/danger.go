@@ -34,6 +34,15 @@ func SubsliceOffset(data []byte, subslice []byte) int {
 	return intoffset
 }
 
+// IsSubslice returns true if subslice is a part of data. Unlike
+// SubsliceOffset, it does not panic when it is not.
+func IsSubslice(data []byte, subslice []byte) bool {
+	datap := (*reflect.SliceHeader)(unsafe.Pointer(&data))
+	hlp := (*reflect.SliceHeader)(unsafe.Pointer(&subslice))
+
+	return hlp.Data >= datap.Data && hlp.Data-datap.Data+uintptr(hlp.Len) <= uintptr(datap.Len)
+}
+
 func BytesRange(start []byte, end []byte) []byte {
 	if start == nil || end == nil {
 		panic("cannot call BytesRange with nil")
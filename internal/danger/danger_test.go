@@ -79,6 +79,17 @@ func TestSubsliceOffsetInvalid(t *testing.T) {
 	}
 }
 
+func TestIsSubslice(t *testing.T) {
+	full := []byte("hello world")
+
+	assert.True(t, danger.IsSubslice(full, full))
+	assert.True(t, danger.IsSubslice(full, full[3:8]))
+	assert.True(t, danger.IsSubslice(full[:5], full[3:5]))
+	assert.False(t, danger.IsSubslice(full[:5], full[3:8]))
+	assert.False(t, danger.IsSubslice(full[5:], full[1:]))
+	assert.False(t, danger.IsSubslice([]byte("one"), []byte("two")))
+}
+
 func TestStride(t *testing.T) {
 	a := []byte{1, 2, 3, 4}
 	x := &a[1]
@@ -3,6 +3,7 @@
 package testsuite
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -24,6 +25,12 @@ func Unmarshal(data []byte, v interface{}) error {
 	return toml.Unmarshal(data, v)
 }
 
+// UnmarshalSpec is a helper function for decoding a document against a given
+// version of the TOML specification.
+func UnmarshalSpec(data []byte, v interface{}, spec toml.SpecVersion) error {
+	return toml.NewDecoder(bytes.NewReader(data)).SetSpecVersion(spec).Decode(v)
+}
+
 // ValueToTaggedJSON takes a data structure and returns the tagged JSON
 // representation.
 func ValueToTaggedJSON(doc interface{}) ([]byte, error) {
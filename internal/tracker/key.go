@@ -6,6 +6,17 @@ import "github.com/pelletier/go-toml/v2/unstable"
 // walked.
 type KeyTracker struct {
 	k []string
+
+	// Number of elements of k that prefix all the keys.
+	base int
+}
+
+// SetRoot makes key the prefix of all the tracked keys, including the keys of
+// tables. It is used to track the keys of a document decoded into the table
+// key of another one.
+func (t *KeyTracker) SetRoot(key []string) {
+	t.k = append(t.k[:0], key...)
+	t.base = len(key)
 }
 
 // UpdateTable sets the state of the tracker with the AST table node.
@@ -44,5 +55,5 @@ func (t *KeyTracker) Key() []string {
 }
 
 func (t *KeyTracker) reset() {
-	t.k = t.k[:0]
+	t.k = t.k[:t.base]
 }
@@ -55,6 +55,15 @@ func (k keyKind) String() string {
 type SeenTracker struct {
 	entries    []entry
 	currentIdx int
+
+	// Index of the entry tables are relative to. It is not the root entry
+	// when checking a document included in a table.
+	rootIdx int
+
+	// CopyNames makes the tracker copy the names of the keys it records,
+	// instead of referencing the document. It is required when the
+	// document does not outlive the tracker.
+	CopyNames bool
 }
 
 var pool sync.Pool
@@ -62,6 +71,7 @@ var pool sync.Pool
 func (s *SeenTracker) reset() {
 	// Always contains a root element at index 0.
 	s.currentIdx = 0
+	s.rootIdx = 0
 	if len(s.entries) == 0 {
 		s.entries = make([]entry, 1, 2)
 	} else {
@@ -113,6 +123,10 @@ func (s *SeenTracker) clear(idx int) {
 }
 
 func (s *SeenTracker) create(parentIdx int, name []byte, kind keyKind, explicit bool, kv bool) int {
+	if s.CopyNames {
+		name = append([]byte(nil), name...)
+	}
+
 	e := entry{
 		child: -1,
 		next:  s.entries[parentIdx].child,
@@ -147,6 +161,32 @@ func (s *SeenTracker) setExplicitFlag(parentIdx int) {
 	}
 }
 
+// Scope is the state of a SeenTracker before a call to EnterScope.
+type Scope struct {
+	root    int
+	current int
+}
+
+// EnterScope makes the current table the parent of the tables defined by the
+// expressions checked until ExitScope is called with the returned Scope. It
+// allows to check the content of a document included in a table as if it was
+// written in that table.
+func (s *SeenTracker) EnterScope() Scope {
+	if s.entries == nil {
+		s.reset()
+	}
+	scope := Scope{root: s.rootIdx, current: s.currentIdx}
+	s.rootIdx = s.currentIdx
+	return scope
+}
+
+// ExitScope restores the state of the tracker before the call to EnterScope
+// that returned scope.
+func (s *SeenTracker) ExitScope(scope Scope) {
+	s.rootIdx = scope.root
+	s.currentIdx = scope.current
+}
+
 // CheckExpression takes a top-level node and checks that it does not contain
 // keys that have been seen in previous calls, and validates that types are
 // consistent. It returns true if it is the first time this node's key is seen.
@@ -174,7 +214,7 @@ func (s *SeenTracker) checkTable(node *unstable.Node) (bool, error) {
 
 	it := node.Key()
 
-	parentIdx := 0
+	parentIdx := s.rootIdx
 
 	// This code is duplicated in checkArrayTable. This is because factoring
 	// it in a function requires to copy the iterator, or allocate it to the
@@ -229,7 +269,7 @@ func (s *SeenTracker) checkArrayTable(node *unstable.Node) (bool, error) {
 
 	it := node.Key()
 
-	parentIdx := 0
+	parentIdx := s.rootIdx
 
 	for it.Next() {
 		if it.IsLast() {
@@ -75,7 +75,7 @@ func (d LocalTime) MarshalText() ([]byte, error) {
 
 // UnmarshalText parses b using RFC 3339 to fill d.
 func (d *LocalTime) UnmarshalText(b []byte) error {
-	res, left, err := parseLocalTime(b)
+	res, left, err := parseLocalTime(b, false)
 	if err == nil && len(left) != 0 {
 		err = unstable.NewParserError(left, "extra characters")
 	}
@@ -109,7 +109,7 @@ func (d LocalDateTime) MarshalText() ([]byte, error) {
 
 // UnmarshalText parses b using RFC 3339 to fill d.
 func (d *LocalDateTime) UnmarshalText(data []byte) error {
-	res, left, err := parseLocalDateTime(data)
+	res, left, err := parseLocalDateTime(data, false)
 	if err == nil && len(left) != 0 {
 		err = unstable.NewParserError(left, "extra characters")
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -11,10 +12,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode"
 
 	"github.com/pelletier/go-toml/v2/internal/characters"
+	"github.com/pelletier/go-toml/v2/internal/danger"
 )
 
 // Marshal serializes a Go value as a TOML document.
@@ -32,6 +35,18 @@ func Marshal(v interface{}) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// Marshaler is implemented by types that customize their TOML representation.
+//
+// MarshalTOML returns a Go value that is encoded in place of the receiver,
+// following the usual encoding rules. For example, a duration can be written
+// as an integer by returning an int64, a set as an array by returning a
+// slice, and any type as a table by returning a map or a struct. Returning nil
+// omits a struct field or a map entry. The returned value should not implement
+// Marshaler itself.
+type Marshaler interface {
+	MarshalTOML() (interface{}, error)
+}
+
 // Encoder writes a TOML document to an output stream.
 type Encoder struct {
 	// output
@@ -43,6 +58,15 @@ type Encoder struct {
 	indentSymbol       string
 	indentTables       bool
 	marshalJsonNumbers bool
+	streaming          bool
+	spec               SpecVersion
+	dottedDepth        int
+	digitSeparators    bool
+	floatExponent      bool
+	maxDepth           int
+
+	// concrete types of interfaces, by interface type
+	variants map[reflect.Type]*variants
 }
 
 // NewEncoder returns a new Encoder that writes to w.
@@ -100,9 +124,91 @@ func (enc *Encoder) SetMarshalJsonNumbers(indent bool) *Encoder {
 	return enc
 }
 
+// SetStreaming makes the encoder write each table and array table element to
+// the output as soon as it has been encoded, instead of writing the whole
+// document at once. The output is the same, but the encoder only needs to
+// hold one table in memory.
+//
+// As a result, if an error occurs, the tables encoded before it have already
+// been written. Errors returned by the underlying writer abort the encoding.
+func (enc *Encoder) SetStreaming(streaming bool) *Encoder {
+	enc.streaming = streaming
+	return enc
+}
+
+// SetSpecVersion selects the version of the TOML specification the document is
+// written for. Defaults to TOML10.
+//
+// With TOML11, control characters in strings are written using the shorter \e
+// and \xHH escape sequences.
+func (enc *Encoder) SetSpecVersion(v SpecVersion) *Encoder {
+	enc.spec = v
+	return enc
+}
+
+// SetDottedTables makes the encoder write the tables whose key has at least
+// depth parts as dotted keys in their parent table, instead of with a table
+// header. For example, with a depth of 2:
+//
+//	[server]
+//	http.port = 8080
+//	http.host = 'localhost'
+//
+// A depth of 1 writes all the tables as dotted keys of the root table. Array
+// tables are still written with a header. Defaults to 0, which disables dotted
+// keys.
+//
+// This behavior can be controlled on an individual struct field basis with the
+// dotted tag:
+//
+//	MyField `toml:",dotted"`
+func (enc *Encoder) SetDottedTables(depth int) *Encoder {
+	enc.dottedDepth = depth
+	return enc
+}
+
+// SetDigitSeparators makes the encoder group the digits of integers, and of the
+// integer part of floats, with underscores. Decimal and octal digits are
+// grouped by three, hexadecimal and binary digits by four. For example,
+// 1000000 is written as 1_000_000.
+//
+// This behavior can be controlled on an individual struct field basis with the
+// separators tag:
+//
+//	MyField `toml:",separators"`
+func (enc *Encoder) SetDigitSeparators(separators bool) *Encoder {
+	enc.digitSeparators = separators
+	return enc
+}
+
+// SetFloatExponent makes the encoder write floats in exponent form when it is
+// shorter than the decimal form. For example, 1e300 is written as 1e300
+// instead of a 301 digits number.
+//
+// This behavior can be controlled on an individual struct field basis with the
+// exponent tag:
+//
+//	MyField `toml:",exponent"`
+func (enc *Encoder) SetFloatExponent(exponent bool) *Encoder {
+	enc.floatExponent = exponent
+	return enc
+}
+
+// SetMaxDepth makes Encode return an error when a value is nested in more than
+// depth tables and arrays. Defaults to 0, which does not limit the depth.
+//
+// Independently of the depth, Encode returns a CycleError when a value contains
+// itself through pointers, maps, or slices.
+func (enc *Encoder) SetMaxDepth(depth int) *Encoder {
+	enc.maxDepth = depth
+	return enc
+}
+
 // Encode writes a TOML representation of v to the stream.
 //
-// If v cannot be represented to TOML it returns an error.
+// If v cannot be represented to TOML it returns an error. Errors about the
+// values of v are of type *EncodeError, and locate the value in the document
+// and in v.
 //
 // # Encoding rules
 //
@@ -112,9 +218,11 @@ func (enc *Encoder) SetMarshalJsonNumbers(indent bool) *Encoder {
 // All slices not matching rule 1 are encoded as [array]. As a result, any map
 // or struct they contain is encoded as an {inline table}.
 //
-// Nil interfaces and nil pointers are not supported.
+// Nil interfaces and nil pointers are not supported. Values containing
+// themselves result in a CycleError.
 //
-// Keys in key-values always have one part.
+// Keys in key-values have one part, unless tables are written as dotted keys.
+// See SetDottedTables.
 //
 // Intermediate tables are always printed.
 //
@@ -132,6 +240,9 @@ func (enc *Encoder) SetMarshalJsonNumbers(indent bool) *Encoder {
 // When encoding structs, fields are encoded in order of definition, with their
 // exact name.
 //
+// Keys of maps can be strings, types implementing encoding.TextMarshaler,
+// integers, or bools. Keys of maps are sorted, by value for integers.
+//
 // Tables and array tables are separated by empty lines. However, consecutive
 // subtables definitions are not. For example:
 //
@@ -145,6 +256,13 @@ func (enc *Encoder) SetMarshalJsonNumbers(indent bool) *Encoder {
 //	[[array]]
 //	[array.child2]
 //
+// # Marshaler interface
+//
+// Types implementing the Marshaler interface are encoded as the value returned
+// by their MarshalTOML method. This takes precedence over the
+// encoding.TextMarshaler interface. At the root of the document, MarshalTOML
+// must return a value that is encoded as a table.
+//
 // # Struct tags
 //
 // The encoding of each public struct field can be customized by the format
@@ -164,10 +282,29 @@ func (enc *Encoder) SetMarshalJsonNumbers(indent bool) *Encoder {
 // The "commented" option prefixes the value and all its children with a comment
 // symbol.
 //
+// The "hex", "octal", and "binary" options write integers in the
+// corresponding base, with the 0x, 0o, and 0b prefixes. Negative integers
+// cannot be written in these bases, and result in an error.
+//
+// The "separators" option groups digits of numbers with underscores. See
+// SetDigitSeparators.
+//
+// The "exponent" option writes floats in exponent form when it is shorter. See
+// SetFloatExponent.
+//
+// Number options also apply to the elements of arrays.
+//
+// The "dotted" option writes a field that would be emitted as a table as dotted
+// keys in the parent table instead, along with its sub-tables. Array tables it
+// contains are still emitted with a header.
+//
+// The "required" option is only used when decoding. See Decoder.Decode.
+//
 // In addition to the "toml" tag struct tag, a "comment" tag can be used to emit
 // a TOML comment before the value being annotated. Comments are ignored inside
 // inline tables. For array tables, the comment is only present before the first
-// element of the array.
+// element of the array. To attach comments at runtime, wrap values in
+// Commented.
 func (enc *Encoder) Encode(v interface{}) error {
 	var (
 		b   []byte
@@ -181,7 +318,23 @@ func (enc *Encoder) Encode(v interface{}) error {
 	}
 
 	b, err := enc.encode(b, ctx, reflect.ValueOf(v))
+	var cerr *CycleError
+	if errors.As(err, &cerr) {
+		err = enc.findCycle(ctx, reflect.ValueOf(v))
+	}
 	if err != nil {
+		var eerr *EncodeError
+		if errors.As(err, &eerr) {
+			eerr.field = strings.TrimPrefix(eerr.field, ".")
+		}
+		if errors.As(err, &cerr) {
+			cerr.Path = strings.TrimPrefix(cerr.Path, ".")
+		}
+		return err
+	}
+
+	if enc.streaming {
+		_, err = enc.flush(b)
 		return err
 	}
 
@@ -193,11 +346,59 @@ func (enc *Encoder) Encode(v interface{}) error {
 	return nil
 }
 
+// findCycle encodes v again, tracking all the values it contains from the
+// start, and returns the resulting error. As cycles are only looked for in
+// deeply nested values, it finds the shortest path of the value that contains
+// itself. The output is discarded.
+func (enc *Encoder) findCycle(ctx encoderCtx, v reflect.Value) error {
+	streaming := enc.streaming
+	enc.streaming = false
+	ctx.ptrLevel = startDetectingCyclesAfter
+	_, err := enc.encode(nil, ctx, v)
+	enc.streaming = streaming
+	return err
+}
+
+// flush writes b to the output when the encoder is streaming, and returns
+// the emptied buffer so that it can be reused.
+func (enc *Encoder) flush(b []byte) ([]byte, error) {
+	if !enc.streaming || len(b) == 0 {
+		return b, nil
+	}
+
+	_, err := enc.w.Write(b)
+	if err != nil {
+		return nil, fmt.Errorf("toml: cannot write: %w", err)
+	}
+
+	return b[:0], nil
+}
+
 type valueOptions struct {
 	multiline bool
 	omitempty bool
 	commented bool
-	comment   string
+	dotted    bool
+
+	// Formatting of numbers.
+	base       uint8
+	separators bool
+	exponent   bool
+
+	comment string
+	// Comment written at the end of the line of the value. Only set for the
+	// values wrapped in a Commented with a trailing comment, to keep the
+	// entries of tables small.
+	trailing *string
+}
+
+// trailingComment returns the comment written at the end of the line of the
+// value, if any.
+func (o valueOptions) trailingComment() string {
+	if o.trailing == nil {
+		return ""
+	}
+	return *o.trailing
 }
 
 type encoderCtx struct {
@@ -206,6 +407,8 @@ type encoderCtx struct {
 
 	// Key that should be used for a KV.
 	key string
+	// Parts of a dotted key preceding key.
+	keyPrefix []string
 	// Extra flag to account for the empty string
 	hasKey bool
 
@@ -227,6 +430,126 @@ type encoderCtx struct {
 
 	// Options coming from struct tags
 	options valueOptions
+
+	// Discriminator written first in the next table, when it holds a value
+	// of an interface with registered variants.
+	variant *entry
+
+	// Number of tables and arrays the current value is nested in.
+	depth int
+
+	// Number of pointers, maps, and slices containing the current value.
+	ptrLevel int
+
+	// Pointers, maps, and slices containing the current value, once
+	// ptrLevel exceeds startDetectingCyclesAfter. Created when the first one
+	// is recorded, and shared by the contexts of the values they contain.
+	visiting map[visitKey]struct{}
+}
+
+// startDetectingCyclesAfter is the number of nested pointers, maps, and slices
+// after which the Encoder starts looking for cycles. Like encoding/json, it
+// avoids the cost of tracking values in the common case: a value that contains
+// itself is still detected once it has been nested that many times, and its
+// path is then reported by findCycle.
+const startDetectingCyclesAfter = 1000
+
+// visitKey identifies a pointer, map, or slice being encoded.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// visit records that v, a non-nil pointer, map, or slice, is being encoded.
+// It returns a CycleError if it already is, that is if v contains itself.
+func (ctx *encoderCtx) visit(v reflect.Value) (visitKey, error) {
+	ctx.ptrLevel++
+	if ctx.ptrLevel <= startDetectingCyclesAfter {
+		return visitKey{}, nil
+	}
+
+	k := visitKey{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		k.len = v.Len()
+	}
+
+	if ctx.visiting == nil {
+		ctx.visiting = map[visitKey]struct{}{}
+	}
+	if _, ok := ctx.visiting[k]; ok {
+		return k, ctx.errorf(v.Type(), &CycleError{Type: v.Type()}, "value of type %s contains itself", v.Type())
+	}
+	ctx.visiting[k] = struct{}{}
+
+	return k, nil
+}
+
+// leave records that the value identified by k has been encoded.
+func (ctx *encoderCtx) leave(k visitKey) {
+	ctx.ptrLevel--
+	if k.typ != nil {
+		delete(ctx.visiting, k)
+	}
+}
+
+// errorf returns an EncodeError for the value of type t at the current key.
+// err is the error that caused it, if any.
+func (ctx *encoderCtx) errorf(t reflect.Type, err error, format string, args ...interface{}) error {
+	return &EncodeError{
+		message: fmt.Sprintf(format, args...),
+		key:     ctx.errorKey(),
+		typ:     t,
+		err:     err,
+	}
+}
+
+// errorKey returns the key of the value being encoded, for errors.
+func (ctx *encoderCtx) errorKey() Key {
+	var key Key
+	key = append(key, ctx.parentKey...)
+	if ctx.hasKey {
+		key = append(key, ctx.keyPrefix...)
+		key = append(key, ctx.key)
+	}
+	return key
+}
+
+// marshalerError returns the error of a value of type t whose MarshalTOML
+// method failed with err. Its key is set by the caller of resolveMarshalers,
+// with setErrorKey.
+func marshalerError(t reflect.Type, err error) error {
+	return &EncodeError{
+		message: fmt.Sprintf("error marshalling %s: %s", t, err),
+		typ:     t,
+		err:     err,
+	}
+}
+
+// setErrorKey sets the key of err, if it is an EncodeError, to the key of the
+// value being encoded.
+func (ctx *encoderCtx) setErrorKey(err error) error {
+	var eerr *EncodeError
+	if errors.As(err, &eerr) {
+		eerr.key = ctx.errorKey()
+	}
+	return err
+}
+
+// prependErrorPath adds the Go path segment of the value containing the one
+// err occurred in to err, if it is an EncodeError or a CycleError. It is
+// called while returning the error, so that the path is only computed on
+// failure.
+func prependErrorPath(err error, segment string) error {
+	var eerr *EncodeError
+	if errors.As(err, &eerr) {
+		eerr.field = segment + eerr.field
+	}
+	var cerr *CycleError
+	if errors.As(err, &cerr) {
+		cerr.Path = segment + cerr.Path
+	}
+	return err
 }
 
 func (ctx *encoderCtx) shiftKey() {
@@ -251,6 +574,15 @@ func (ctx *encoderCtx) isRoot() bool {
 }
 
 func (enc *Encoder) encode(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, error) {
+	isMarshaler := implementsMarshaler(v)
+	v, err := resolveMarshalers(v)
+	if err != nil {
+		return nil, ctx.setErrorKey(err)
+	}
+	if isMarshaler && ctx.isRoot() && !isTable(v) {
+		return nil, ctx.errorf(v.Type(), nil, "type %s implementing the Marshaler interface must marshal to a table when it is the root element", v.Type())
+	}
+
 	i := v.Interface()
 
 	switch x := i.(type) {
@@ -274,7 +606,7 @@ func (enc *Encoder) encode(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, e
 			} else if f, err := x.Float64(); err == nil {
 				return enc.encode(b, ctx, reflect.ValueOf(f))
 			} else {
-				return nil, fmt.Errorf("toml: unable to convert %q to int64 or float64", x)
+				return nil, ctx.errorf(reflect.TypeOf(x), nil, "unable to convert %q to int64 or float64", x)
 			}
 		}
 	}
@@ -286,12 +618,12 @@ func (enc *Encoder) encode(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, e
 		}
 
 		if ctx.isRoot() {
-			return nil, fmt.Errorf("toml: type %s implementing the TextMarshaler interface cannot be a root element", v.Type())
+			return nil, ctx.errorf(v.Type(), nil, "type %s implementing the TextMarshaler interface cannot be a root element", v.Type())
 		}
 
 		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
 		if err != nil {
-			return nil, err
+			return nil, ctx.errorf(v.Type(), err, "error marshalling %s to text: %s", v.Type(), err)
 		}
 
 		b = enc.encodeString(b, string(text), ctx.options)
@@ -299,57 +631,69 @@ func (enc *Encoder) encode(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, e
 		return b, nil
 	}
 
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		ctx.depth++
+		if enc.maxDepth > 0 && ctx.depth > enc.maxDepth {
+			return nil, ctx.errorf(v.Type(), nil, "maximum depth of %d exceeded", enc.maxDepth)
+		}
+	}
+
 	switch v.Kind() {
 	// containers
 	case reflect.Map:
-		return enc.encodeMap(b, ctx, v)
+		if v.IsNil() {
+			return enc.encodeMap(b, ctx, v)
+		}
+
+		k, err := ctx.visit(v)
+		if err != nil {
+			return nil, err
+		}
+		b, err = enc.encodeMap(b, ctx, v)
+		ctx.leave(k)
+
+		return b, err
 	case reflect.Struct:
+		switch v.Type() {
+		case orderedMapType:
+			m := v.Interface().(OrderedMap)
+			return enc.encodeOrderedMap(b, ctx, &m)
+		case commentedType:
+			return enc.encode(b, ctx, unwrapCommented(v, &ctx.options))
+		}
 		return enc.encodeStruct(b, ctx, v)
 	case reflect.Slice, reflect.Array:
 		return enc.encodeSlice(b, ctx, v)
 	case reflect.Interface:
 		if v.IsNil() {
-			return nil, fmt.Errorf("toml: encoding a nil interface is not supported")
+			return nil, ctx.errorf(v.Type(), nil, "encoding a nil interface is not supported")
 		}
 
+		ctx.variant = enc.variantEntry(v)
+
 		return enc.encode(b, ctx, v.Elem())
 	case reflect.Ptr:
 		if v.IsNil() {
 			return enc.encode(b, ctx, reflect.Zero(v.Type().Elem()))
 		}
 
-		return enc.encode(b, ctx, v.Elem())
+		k, err := ctx.visit(v)
+		if err != nil {
+			return nil, err
+		}
+		b, err = enc.encode(b, ctx, v.Elem())
+		ctx.leave(k)
+
+		return b, err
 
 	// values
 	case reflect.String:
 		b = enc.encodeString(b, v.String(), ctx.options)
 	case reflect.Float32:
-		f := v.Float()
-
-		if math.IsNaN(f) {
-			b = append(b, "nan"...)
-		} else if f > math.MaxFloat32 {
-			b = append(b, "inf"...)
-		} else if f < -math.MaxFloat32 {
-			b = append(b, "-inf"...)
-		} else if math.Trunc(f) == f {
-			b = strconv.AppendFloat(b, f, 'f', 1, 32)
-		} else {
-			b = strconv.AppendFloat(b, f, 'f', -1, 32)
-		}
+		b = enc.encodeFloat(b, v.Float(), 32, ctx.options)
 	case reflect.Float64:
-		f := v.Float()
-		if math.IsNaN(f) {
-			b = append(b, "nan"...)
-		} else if f > math.MaxFloat64 {
-			b = append(b, "inf"...)
-		} else if f < -math.MaxFloat64 {
-			b = append(b, "-inf"...)
-		} else if math.Trunc(f) == f {
-			b = strconv.AppendFloat(b, f, 'f', 1, 64)
-		} else {
-			b = strconv.AppendFloat(b, f, 'f', -1, 64)
-		}
+		b = enc.encodeFloat(b, v.Float(), 64, ctx.options)
 	case reflect.Bool:
 		if v.Bool() {
 			b = append(b, "true"...)
@@ -359,18 +703,151 @@ func (enc *Encoder) encode(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, e
 	case reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8, reflect.Uint:
 		x := v.Uint()
 		if x > uint64(math.MaxInt64) {
-			return nil, fmt.Errorf("toml: not encoding uint (%d) greater than max int64 (%d)", x, int64(math.MaxInt64))
+			return nil, ctx.errorf(v.Type(), nil, "not encoding uint (%d) greater than max int64 (%d)", x, int64(math.MaxInt64))
 		}
-		b = strconv.AppendUint(b, x, 10)
+		return enc.encodeInteger(b, ctx, v.Type(), false, x)
 	case reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8, reflect.Int:
-		b = strconv.AppendInt(b, v.Int(), 10)
+		x := v.Int()
+		if x < 0 {
+			// Also correct for math.MinInt64, whose negation overflows.
+			return enc.encodeInteger(b, ctx, v.Type(), true, uint64(-x))
+		}
+		return enc.encodeInteger(b, ctx, v.Type(), false, uint64(x))
 	default:
-		return nil, fmt.Errorf("toml: cannot encode value of type %s", v.Kind())
+		return nil, ctx.errorf(v.Type(), nil, "cannot encode value of type %s", v.Kind())
 	}
 
 	return b, nil
 }
 
+// isTable returns true if v is a map or a struct, possibly behind pointers and
+// interfaces.
+func isTable(v reflect.Value) bool {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v.Kind() == reflect.Map || v.Kind() == reflect.Struct
+}
+
+func implementsMarshaler(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return false
+	}
+	m := typeMarshaler(v.Type())
+	return m&marshalerValue != 0 || (v.CanAddr() && m&marshalerPointer != 0)
+}
+
+// marshalerKind describes how a type relates to the Marshaler interface.
+type marshalerKind uint8
+
+const (
+	// The type implements Marshaler.
+	marshalerValue marshalerKind = 1 << iota
+	// A pointer to the type implements Marshaler.
+	marshalerPointer
+	// The type is a slice or an array whose elements may implement Marshaler.
+	marshalerElems
+)
+
+var globalMarshalerCache atomic.Value // map[danger.TypeID]marshalerKind
+
+// typeMarshaler returns how t relates to the Marshaler interface.
+func typeMarshaler(t reflect.Type) marshalerKind {
+	cache, _ := globalMarshalerCache.Load().(map[danger.TypeID]marshalerKind)
+	m, ok := cache[danger.MakeTypeID(t)]
+	if ok {
+		return m
+	}
+
+	if t.Implements(marshalerType) {
+		m |= marshalerValue
+	}
+	if reflect.PtrTo(t).Implements(marshalerType) {
+		m |= marshalerPointer
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		elem := t.Elem()
+		if elem.Kind() == reflect.Interface || elem.Implements(marshalerType) || reflect.PtrTo(elem).Implements(marshalerType) {
+			m |= marshalerElems
+		}
+	}
+
+	newCache := make(map[danger.TypeID]marshalerKind, len(cache)+1)
+	newCache[danger.MakeTypeID(t)] = m
+	for k, v := range cache {
+		newCache[k] = v
+	}
+	globalMarshalerCache.Store(newCache)
+
+	return m
+}
+
+// resolveMarshalers returns the value to encode in place of v. If v implements
+// the Marshaler interface, it is the result of MarshalTOML. If v is a slice or
+// an array containing Marshalers, it is a []interface{} containing the result
+// of MarshalTOML for those elements.
+//
+// This is needed before deciding whether a value is encoded as a table.
+func resolveMarshalers(v reflect.Value) (reflect.Value, error) {
+	if v.Kind() == reflect.Interface && implementsMarshaler(v.Elem()) {
+		v = v.Elem()
+	}
+
+	if implementsMarshaler(v) {
+		if typeMarshaler(v.Type())&marshalerValue == 0 {
+			v = v.Addr()
+		}
+
+		x, err := v.Interface().(Marshaler).MarshalTOML()
+		if err != nil {
+			return reflect.Value{}, marshalerError(v.Type(), err)
+		}
+		if x == nil {
+			return reflect.Zero(emptyInterfaceType), nil
+		}
+
+		return reflect.ValueOf(x), nil
+	}
+
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || typeMarshaler(v.Type())&marshalerElems == 0 {
+		return v, nil
+	}
+
+	var elems []interface{}
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		if elems == nil && !implementsMarshaler(e) && !(e.Kind() == reflect.Interface && implementsMarshaler(e.Elem())) {
+			continue
+		}
+
+		if elems == nil {
+			elems = make([]interface{}, i, v.Len())
+			for j := 0; j < i; j++ {
+				elems[j] = v.Index(j).Interface()
+			}
+		}
+
+		x, err := resolveMarshalers(e)
+		if err != nil {
+			return reflect.Value{}, prependErrorPath(err, "["+strconv.Itoa(i)+"]")
+		}
+		if !x.IsValid() || (x.Kind() == reflect.Interface && x.IsNil()) {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, x.Interface())
+		}
+	}
+
+	if elems == nil {
+		return v, nil
+	}
+
+	return reflect.ValueOf(elems), nil
+}
+
 func isNil(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Ptr, reflect.Interface, reflect.Map:
@@ -387,12 +864,19 @@ func shouldOmitEmpty(options valueOptions, v reflect.Value) bool {
 func (enc *Encoder) encodeKv(b []byte, ctx encoderCtx, options valueOptions, v reflect.Value) ([]byte, error) {
 	var err error
 
-	if !ctx.inline {
+	// Comments are not allowed inside inline tables.
+	comments := !ctx.inline && !ctx.insideKv
+
+	if comments {
 		b = enc.encodeComment(ctx.indent, options.comment, b)
 		b = enc.commented(ctx.commented, b)
 		b = enc.indent(ctx.indent, b)
 	}
 
+	for _, k := range ctx.keyPrefix {
+		b = enc.encodeKey(b, k)
+		b = append(b, '.')
+	}
 	b = enc.encodeKey(b, ctx.key)
 	b = append(b, " = "...)
 
@@ -400,6 +884,10 @@ func (enc *Encoder) encodeKv(b []byte, ctx encoderCtx, options valueOptions, v r
 	// modify the global context.
 	subctx := ctx
 	subctx.insideKv = true
+	if len(ctx.keyPrefix) > 0 {
+		subctx.parentKey = append(ctx.parentKey[:len(ctx.parentKey):len(ctx.parentKey)], ctx.keyPrefix...)
+		subctx.keyPrefix = nil
+	}
 	subctx.shiftKey()
 	subctx.options = options
 
@@ -408,6 +896,10 @@ func (enc *Encoder) encodeKv(b []byte, ctx encoderCtx, options valueOptions, v r
 		return nil, err
 	}
 
+	if comments {
+		b = enc.encodeTrailingComment(options.trailingComment(), b)
+	}
+
 	return b, nil
 }
 
@@ -421,6 +913,9 @@ func (enc *Encoder) commented(commented bool, b []byte) []byte {
 func isEmptyValue(v reflect.Value) bool {
 	switch v.Kind() {
 	case reflect.Struct:
+		if v.Type() == orderedMapType {
+			return v.Field(0).Len() == 0
+		}
 		return isEmptyStruct(v)
 	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
 		return v.Len() == 0
@@ -466,6 +961,126 @@ func isEmptyStruct(v reflect.Value) bool {
 	return true
 }
 
+// encodeInteger writes the integer of absolute value u, in the base selected
+// by the options of ctx. t is the type of the encoded value.
+func (enc *Encoder) encodeInteger(b []byte, ctx encoderCtx, t reflect.Type, negative bool, u uint64) ([]byte, error) {
+	options := ctx.options
+	separators := options.separators || enc.digitSeparators
+
+	var (
+		base   = int(options.base)
+		prefix string
+		group  = 3
+	)
+	switch base {
+	case 16:
+		prefix, group = "0x", 4
+	case 8:
+		prefix = "0o"
+	case 2:
+		prefix, group = "0b", 4
+	default:
+		base = 10
+		if negative {
+			b = append(b, '-')
+		}
+		if !separators {
+			return strconv.AppendUint(b, u, 10), nil
+		}
+	}
+
+	if negative && base != 10 {
+		return nil, ctx.errorf(t, nil, "cannot encode negative integer -%d in base %d", u, base)
+	}
+
+	var digits [64]byte
+	d := strconv.AppendUint(digits[:0], u, base)
+
+	b = append(b, prefix...)
+	if separators {
+		return appendGroupedDigits(b, d, group), nil
+	}
+	return append(b, d...), nil
+}
+
+// encodeFloat writes f, a float of the given bit size.
+func (enc *Encoder) encodeFloat(b []byte, f float64, bitSize int, options valueOptions) []byte {
+	max := math.MaxFloat64
+	if bitSize == 32 {
+		max = math.MaxFloat32
+	}
+
+	switch {
+	case math.IsNaN(f):
+		return append(b, "nan"...)
+	case f > max:
+		return append(b, "inf"...)
+	case f < -max:
+		return append(b, "-inf"...)
+	}
+
+	start := len(b)
+	if math.Trunc(f) == f {
+		b = strconv.AppendFloat(b, f, 'f', 1, bitSize)
+	} else {
+		b = strconv.AppendFloat(b, f, 'f', -1, bitSize)
+	}
+
+	if options.exponent || enc.floatExponent {
+		e := appendFloatExponent(nil, f, bitSize)
+		if len(e) < len(b)-start {
+			return append(b[:start], e...)
+		}
+	}
+
+	if options.separators || enc.digitSeparators {
+		decimal := append([]byte(nil), b[start:]...)
+		b = b[:start]
+		if decimal[0] == '-' {
+			b = append(b, '-')
+			decimal = decimal[1:]
+		}
+		dot := bytes.IndexByte(decimal, '.')
+		b = appendGroupedDigits(b, decimal[:dot], 3)
+		b = append(b, decimal[dot:]...)
+	}
+
+	return b
+}
+
+// appendFloatExponent writes f in exponent form, without the plus sign and
+// the leading zeros of the exponent.
+func appendFloatExponent(b []byte, f float64, bitSize int) []byte {
+	start := len(b)
+	b = strconv.AppendFloat(b, f, 'e', -1, bitSize)
+
+	i := start + bytes.IndexByte(b[start:], 'e') + 1
+	if b[i] == '-' {
+		i++
+	}
+	j := i
+	if b[j] == '+' {
+		j++
+	}
+	for j < len(b)-1 && b[j] == '0' {
+		j++
+	}
+
+	return append(b[:i], b[j:]...)
+}
+
+// appendGroupedDigits writes digits separated by underscores every group
+// digits, starting from the right.
+func appendGroupedDigits(b []byte, digits []byte, group int) []byte {
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%group == 0 {
+			b = append(b, '_')
+		}
+		b = append(b, d)
+	}
+	return b
+}
+
 const literalQuote = '\''
 
 func (enc *Encoder) encodeString(b []byte, v string, options valueOptions) []byte {
@@ -513,10 +1128,16 @@ func (enc *Encoder) encodeQuotedString(multiline bool, b []byte, v string) []byt
 		nul = 0x0
 		bs  = 0x8
 		lf  = 0xa
+		esc = 0x1b
 		us  = 0x1f
 		del = 0x7f
 	)
 
+	hexEscape := `\u00`
+	if enc.spec >= TOML11 {
+		hexEscape = `\x`
+	}
+
 	for _, r := range []byte(v) {
 		switch r {
 		case '\\':
@@ -539,8 +1160,10 @@ func (enc *Encoder) encodeQuotedString(multiline bool, b []byte, v string) []byt
 			b = append(b, `\t`...)
 		default:
 			switch {
+			case r == esc && enc.spec >= TOML11:
+				b = append(b, `\e`...)
 			case r >= nul && r <= bs, r >= lf && r <= us, r == del:
-				b = append(b, `\u00`...)
+				b = append(b, hexEscape...)
 				b = append(b, hextable[r>>4])
 				b = append(b, hextable[r&0x0f])
 			default:
@@ -579,7 +1202,9 @@ func (enc *Encoder) encodeTableHeader(ctx encoderCtx, b []byte) ([]byte, error)
 		b = enc.encodeKey(b, k)
 	}
 
-	b = append(b, "]\n"...)
+	b = append(b, ']')
+	b = enc.encodeTrailingComment(ctx.options.trailingComment(), b)
+	b = append(b, '\n')
 
 	return b, nil
 }
@@ -619,7 +1244,7 @@ func (enc *Encoder) encodeKey(b []byte, k string) []byte {
 	}
 }
 
-func (enc *Encoder) keyToString(k reflect.Value) (string, error) {
+func (enc *Encoder) keyToString(ctx encoderCtx, k reflect.Value) (string, error) {
 	keyType := k.Type()
 	switch {
 	case keyType.Kind() == reflect.String:
@@ -628,48 +1253,99 @@ func (enc *Encoder) keyToString(k reflect.Value) (string, error) {
 	case keyType.Implements(textMarshalerType):
 		keyB, err := k.Interface().(encoding.TextMarshaler).MarshalText()
 		if err != nil {
-			return "", fmt.Errorf("toml: error marshalling key %v from text: %w", k, err)
+			return "", ctx.errorf(keyType, err, "error marshalling key %v from text: %s", k, err)
 		}
 		return string(keyB), nil
 	}
-	return "", fmt.Errorf("toml: type %s is not supported as a map key", keyType.Kind())
+
+	switch keyType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool()), nil
+	}
+
+	return "", ctx.errorf(keyType, nil, "type %s is not supported as a map key", keyType.Kind())
 }
 
 func (enc *Encoder) encodeMap(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, error) {
-	var (
-		t                 table
-		emptyValueOptions valueOptions
-	)
+	t, err := enc.mapTable(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	ctx.variant = nil
+
+	return enc.encodeTable(b, ctx, t)
+}
+
+// mapTable returns the entries of the map v, sorted by key.
+func (enc *Encoder) mapTable(ctx encoderCtx, v reflect.Value) (table, error) {
+	var t table
 
 	iter := v.MapRange()
 	for iter.Next() {
-		v := iter.Value()
+		var options valueOptions
+		v, err := resolveMarshalers(unwrapCommented(iter.Value(), &options))
+		if err != nil {
+			k, kerr := enc.keyToString(ctx, iter.Key())
+			if kerr != nil {
+				return table{}, kerr
+			}
+			ctx.shiftKey()
+			ctx.setKey(k)
+			return table{}, prependErrorPath(ctx.setErrorKey(err), "["+strconv.Quote(k)+"]")
+		}
 
 		if isNil(v) {
 			continue
 		}
 
-		k, err := enc.keyToString(iter.Key())
+		k, err := enc.keyToString(ctx, iter.Key())
 		if err != nil {
-			return nil, err
+			return table{}, err
 		}
 
 		if willConvertToTableOrArrayTable(ctx, v) {
-			t.pushTable(k, v, emptyValueOptions)
+			t.pushTable(k, v, options, "")
 		} else {
-			t.pushKV(k, v, emptyValueOptions)
+			t.pushKV(k, v, options, "")
 		}
 	}
 
-	sortEntriesByKey(t.kvs)
-	sortEntriesByKey(t.tables)
+	sortEntriesByKey(v.Type().Key(), t.kvs)
+	sortEntriesByKey(v.Type().Key(), t.tables)
 
-	return enc.encodeTable(b, ctx, t)
+	t.pushVariant(ctx.variant)
+
+	return t, nil
 }
 
-func sortEntriesByKey(e []entry) {
+// sortEntriesByKey sorts the entries of a map with keys of type keyType.
+// Integer keys are sorted by value.
+func sortEntriesByKey(keyType reflect.Type, e []entry) {
+	less := func(a, b string) bool { return a < b }
+
+	if !keyType.Implements(textMarshalerType) {
+		switch keyType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			less = func(a, b string) bool {
+				x, _ := strconv.ParseInt(a, 10, 64)
+				y, _ := strconv.ParseInt(b, 10, 64)
+				return x < y
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			less = func(a, b string) bool {
+				x, _ := strconv.ParseUint(a, 10, 64)
+				y, _ := strconv.ParseUint(b, 10, 64)
+				return x < y
+			}
+		}
+	}
+
 	sort.Slice(e, func(i, j int) bool {
-		return e[i].Key < e[j].Key
+		return less(e[i].Key, e[j].Key)
 	})
 }
 
@@ -677,6 +1353,43 @@ type entry struct {
 	Key     string
 	Value   reflect.Value
 	Options valueOptions
+
+	// Name of the struct field the entry comes from. Empty for the entries
+	// of maps.
+	Field string
+
+	// Table written as dotted keys the entry belongs to, if any. It is
+	// shared by the entries of that table.
+	Dotted *dottedTable
+}
+
+// dottedTable is a table whose entries are written in the current table,
+// with dotted keys.
+type dottedTable struct {
+	// Parts of the dotted key preceding the keys of the entries.
+	prefix []string
+	// Go path of the table, relative to the current table.
+	goPath string
+}
+
+// prefix returns the parts of the dotted key preceding Key.
+func (e entry) prefix() []string {
+	if e.Dotted == nil {
+		return nil
+	}
+	return e.Dotted.prefix
+}
+
+// goPath returns the Go path of the entry, relative to the current table.
+func (e entry) goPath() string {
+	var prefix string
+	if e.Dotted != nil {
+		prefix = e.Dotted.goPath
+	}
+	if e.Field != "" {
+		return prefix + "." + e.Field
+	}
+	return prefix + "[" + strconv.Quote(e.Key) + "]"
 }
 
 type table struct {
@@ -684,26 +1397,42 @@ type table struct {
 	tables []entry
 }
 
-func (t *table) pushKV(k string, v reflect.Value, options valueOptions) {
+func (t *table) pushKV(k string, v reflect.Value, options valueOptions, field string) {
 	for _, e := range t.kvs {
 		if e.Key == k {
 			return
 		}
 	}
 
-	t.kvs = append(t.kvs, entry{Key: k, Value: v, Options: options})
+	t.kvs = append(t.kvs, entry{Key: k, Value: v, Options: options, Field: field})
+}
+
+// pushVariant inserts the discriminator e, if any, before the other
+// key-values, replacing the one of the same key.
+func (t *table) pushVariant(e *entry) {
+	if e == nil {
+		return
+	}
+
+	kvs := []entry{*e}
+	for _, kv := range t.kvs {
+		if kv.Key != e.Key {
+			kvs = append(kvs, kv)
+		}
+	}
+	t.kvs = kvs
 }
 
-func (t *table) pushTable(k string, v reflect.Value, options valueOptions) {
+func (t *table) pushTable(k string, v reflect.Value, options valueOptions, field string) {
 	for _, e := range t.tables {
 		if e.Key == k {
 			return
 		}
 	}
-	t.tables = append(t.tables, entry{Key: k, Value: v, Options: options})
+	t.tables = append(t.tables, entry{Key: k, Value: v, Options: options, Field: field})
 }
 
-func walkStruct(ctx encoderCtx, t *table, v reflect.Value) {
+func walkStruct(ctx encoderCtx, t *table, v reflect.Value) error {
 	// TODO: cache this
 	typ := v.Type()
 	for i := 0; i < typ.NumField(); i++ {
@@ -730,10 +1459,20 @@ func walkStruct(ctx encoderCtx, t *table, v reflect.Value) {
 
 		if k == "" {
 			if fieldType.Anonymous {
+				var err error
 				if fieldType.Type.Kind() == reflect.Struct {
-					walkStruct(ctx, t, f)
+					err = walkStruct(ctx, t, f)
 				} else if fieldType.Type.Kind() == reflect.Pointer && !f.IsNil() && f.Elem().Kind() == reflect.Struct {
-					walkStruct(ctx, t, f.Elem())
+					var k visitKey
+					k, err = ctx.visit(f)
+					if err == nil {
+						err = walkStruct(ctx, t, f.Elem())
+						ctx.leave(k)
+					}
+					err = prependErrorPath(err, "."+fieldType.Name)
+				}
+				if err != nil {
+					return err
 				}
 				continue
 			} else {
@@ -741,31 +1480,196 @@ func walkStruct(ctx encoderCtx, t *table, v reflect.Value) {
 			}
 		}
 
-		if isNil(f) {
-			continue
-		}
-
 		options := valueOptions{
 			multiline: opts.multiline,
 			omitempty: opts.omitempty,
 			commented: opts.commented,
 			comment:   fieldType.Tag.Get("comment"),
+			dotted:    opts.dotted,
+
+			base:       opts.base,
+			separators: opts.separators,
+			exponent:   opts.exponent,
+		}
+
+		f, err := resolveMarshalers(unwrapCommented(f, &options))
+		if err != nil {
+			ctx.shiftKey()
+			ctx.setKey(k)
+			return prependErrorPath(ctx.setErrorKey(err), "."+fieldType.Name)
+		}
+
+		if isNil(f) {
+			continue
 		}
 
 		if opts.inline || !willConvertToTableOrArrayTable(ctx, f) {
-			t.pushKV(k, f, options)
+			t.pushKV(k, f, options, fieldType.Name)
 		} else {
-			t.pushTable(k, f, options)
+			t.pushTable(k, f, options, fieldType.Name)
 		}
 	}
+
+	return nil
 }
 
 func (enc *Encoder) encodeStruct(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, error) {
+	t, err := enc.structTable(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	ctx.variant = nil
+
+	return enc.encodeTable(b, ctx, t)
+}
+
+// structTable returns the entries of the struct v, in order of definition.
+func (enc *Encoder) structTable(ctx encoderCtx, v reflect.Value) (table, error) {
 	var t table
 
-	walkStruct(ctx, &t, v)
+	if ctx.variant != nil {
+		t.kvs = append(t.kvs, *ctx.variant)
+	}
 
-	return enc.encodeTable(b, ctx, t)
+	err := walkStruct(ctx, &t, v)
+	if err != nil {
+		return table{}, err
+	}
+
+	return t, nil
+}
+
+// tableOf returns the entries of v, a value encoded as a table.
+func (enc *Encoder) tableOf(ctx encoderCtx, v reflect.Value) (table, error) {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.Kind() == reflect.Interface {
+			ctx.variant = enc.variantEntry(v)
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Map:
+		return enc.mapTable(ctx, v)
+	case v.Type() == orderedMapType:
+		m := v.Interface().(OrderedMap)
+		return enc.orderedMapTable(ctx, &m)
+	default:
+		return enc.structTable(ctx, v)
+	}
+}
+
+// isDotted returns true if the table e of the table at the current key is
+// written as dotted keys.
+func (enc *Encoder) isDotted(ctx encoderCtx, e entry) bool {
+	if !e.Options.dotted && (enc.dottedDepth <= 0 || len(ctx.parentKey)+1 < enc.dottedDepth) {
+		return false
+	}
+	return willConvertToTable(ctx, e.Value) && !shouldOmitEmpty(e.Options, e.Value)
+}
+
+// dottedTables replaces the tables of t written as dotted keys by their
+// key-values. The array tables they contain are kept as tables, with a dotted
+// key prefix.
+func (enc *Encoder) dottedTables(ctx encoderCtx, t table) (table, error) {
+	var out table
+	for i, e := range t.tables {
+		if !enc.isDotted(ctx, e) {
+			if out.tables != nil {
+				out.tables = append(out.tables, e)
+			}
+			continue
+		}
+
+		if out.tables == nil {
+			out.kvs = append(out.kvs, t.kvs...)
+			out.tables = append(make([]entry, 0, len(t.tables)), t.tables[:i]...)
+		}
+
+		err := enc.pushDotted(ctx, &out, e)
+		if err != nil {
+			return table{}, err
+		}
+	}
+
+	if out.tables == nil {
+		return t, nil
+	}
+	return out, nil
+}
+
+// pushDotted adds the key-values of the table e and of its sub-tables to t as
+// dotted keys, and its array tables as tables with a dotted key prefix. An
+// empty table is added as a key-value, so that it is still defined.
+func (enc *Encoder) pushDotted(ctx encoderCtx, t *table, e entry) error {
+	// The sub-tables are flattened without going through encode, so cycles
+	// are detected here.
+	for v := e.Value; v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || v.Kind() == reflect.Map; v = v.Elem() {
+		if v.Kind() == reflect.Interface {
+			continue
+		}
+		k, err := ctx.visit(v)
+		if err != nil {
+			return prependErrorPath(err, e.goPath())
+		}
+		defer ctx.leave(k)
+		if v.Kind() == reflect.Map {
+			break
+		}
+	}
+
+	sub, err := enc.tableOf(ctx, e.Value)
+	if err != nil {
+		return prependErrorPath(err, e.goPath())
+	}
+
+	parent := e.prefix()
+	dotted := &dottedTable{
+		prefix: append(parent[:len(parent):len(parent)], e.Key),
+		goPath: e.goPath(),
+	}
+	first := len(t.kvs)
+	tables := len(t.tables)
+
+	for _, kv := range sub.kvs {
+		if shouldOmitEmpty(kv.Options, kv.Value) {
+			continue
+		}
+		kv.Dotted = dotted
+		kv.Options.commented = kv.Options.commented || e.Options.commented
+		t.kvs = append(t.kvs, kv)
+	}
+
+	for _, st := range sub.tables {
+		if shouldOmitEmpty(st.Options, st.Value) {
+			continue
+		}
+		st.Dotted = dotted
+		st.Options.commented = st.Options.commented || e.Options.commented
+		if willConvertToTable(ctx, st.Value) {
+			err := enc.pushDotted(ctx, t, st)
+			if err != nil {
+				return err
+			}
+		} else {
+			t.tables = append(t.tables, st)
+		}
+	}
+
+	switch {
+	case len(t.kvs) == first && len(t.tables) == tables:
+		e.Options.dotted = false
+		t.kvs = append(t.kvs, e)
+	case len(t.kvs) > first && e.Options.comment != "":
+		kv := &t.kvs[first]
+		if kv.Options.comment != "" {
+			kv.Options.comment = e.Options.comment + "\n" + kv.Options.comment
+		} else {
+			kv.Options.comment = e.Options.comment
+		}
+	}
+
+	return nil
 }
 
 func (enc *Encoder) encodeComment(indent int, comment string, b []byte) []byte {
@@ -809,6 +1713,12 @@ type tagOptions struct {
 	inline    bool
 	omitempty bool
 	commented bool
+	required  bool
+	dotted    bool
+
+	base       uint8
+	separators bool
+	exponent   bool
 }
 
 func parseTag(tag string) (string, tagOptions) {
@@ -838,6 +1748,20 @@ func parseTag(tag string) (string, tagOptions) {
 			opts.omitempty = true
 		case "commented":
 			opts.commented = true
+		case "required":
+			opts.required = true
+		case "dotted":
+			opts.dotted = true
+		case "hex":
+			opts.base = 16
+		case "octal":
+			opts.base = 8
+		case "binary":
+			opts.base = 2
+		case "separators":
+			opts.separators = true
+		case "exponent":
+			opts.exponent = true
 		}
 	}
 
@@ -853,6 +1777,11 @@ func (enc *Encoder) encodeTable(b []byte, ctx encoderCtx, t table) ([]byte, erro
 		return enc.encodeTableInline(b, ctx, t)
 	}
 
+	t, err = enc.dottedTables(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
 	if !ctx.skipTableHeader {
 		b, err = enc.encodeTableHeader(ctx, b)
 		if err != nil {
@@ -874,11 +1803,12 @@ func (enc *Encoder) encodeTable(b []byte, ctx encoderCtx, t table) ([]byte, erro
 
 		ctx.setKey(kv.Key)
 		ctx2 := ctx
+		ctx2.keyPrefix = kv.prefix()
 		ctx2.commented = kv.Options.commented || ctx2.commented
 
 		b, err = enc.encodeKv(b, ctx2, kv.Options, kv.Value)
 		if err != nil {
-			return nil, err
+			return nil, prependErrorPath(err, kv.goPath())
 		}
 
 		b = append(b, '\n')
@@ -902,9 +1832,17 @@ func (enc *Encoder) encodeTable(b []byte, ctx encoderCtx, t table) ([]byte, erro
 
 		ctx.options = table.Options
 		ctx2 := ctx
+		if prefix := table.prefix(); len(prefix) > 0 {
+			ctx2.parentKey = append(ctx.parentKey[:len(ctx.parentKey):len(ctx.parentKey)], prefix...)
+		}
 		ctx2.commented = ctx2.commented || ctx.options.commented
 
 		b, err = enc.encode(b, ctx2, table.Value)
+		if err != nil {
+			return nil, prependErrorPath(err, table.goPath())
+		}
+
+		b, err = enc.flush(b)
 		if err != nil {
 			return nil, err
 		}
@@ -934,7 +1872,7 @@ func (enc *Encoder) encodeTableInline(b []byte, ctx encoderCtx, t table) ([]byte
 
 		b, err = enc.encodeKv(b, ctx, kv.Options, kv.Value)
 		if err != nil {
-			return nil, err
+			return nil, prependErrorPath(err, kv.goPath())
 		}
 	}
 
@@ -951,6 +1889,9 @@ func willConvertToTable(ctx encoderCtx, v reflect.Value) bool {
 	if !v.IsValid() {
 		return false
 	}
+	if c, ok := commentedValue(v); ok {
+		return willConvertToTable(ctx, reflect.ValueOf(c.Value))
+	}
 	if v.Type() == timeType || v.Type().Implements(textMarshalerType) || (v.Kind() != reflect.Ptr && v.CanAddr() && reflect.PtrTo(v.Type()).Implements(textMarshalerType)) {
 		return false
 	}
@@ -1003,12 +1944,27 @@ func willConvertToTableOrArrayTable(ctx encoderCtx, v reflect.Value) bool {
 }
 
 func (enc *Encoder) encodeSlice(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, error) {
+	ctx.variant = nil
+
 	if v.Len() == 0 {
 		b = append(b, "[]"...)
 
 		return b, nil
 	}
 
+	// Only slices whose elements can reference other values can contain
+	// themselves.
+	if v.Kind() == reflect.Slice {
+		switch v.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			k, err := ctx.visit(v)
+			if err != nil {
+				return nil, err
+			}
+			defer ctx.leave(k)
+		}
+	}
+
 	if willConvertToTableOrArrayTable(ctx, v) {
 		return enc.encodeSliceAsArrayTable(b, ctx, v)
 	}
@@ -1035,11 +1991,10 @@ func (enc *Encoder) encodeSliceAsArrayTable(b []byte, ctx encoderCtx, v reflect.
 		scratch = enc.encodeKey(scratch, k)
 	}
 
-	scratch = append(scratch, "]]\n"...)
+	scratch = append(scratch, "]]"...)
 	ctx.skipTableHeader = true
 
-	b = enc.encodeComment(ctx.indent, ctx.options.comment, b)
-
+	indent := ctx.indent
 	if enc.indentTables {
 		ctx.indent++
 	}
@@ -1049,10 +2004,24 @@ func (enc *Encoder) encodeSliceAsArrayTable(b []byte, ctx encoderCtx, v reflect.
 			b = append(b, "\n"...)
 		}
 
+		var options valueOptions
+		if i == 0 {
+			options = ctx.options
+		}
+		e := unwrapCommented(v.Index(i), &options)
+
+		b = enc.encodeComment(indent, options.comment, b)
 		b = append(b, scratch...)
+		b = enc.encodeTrailingComment(options.trailingComment(), b)
+		b = append(b, '\n')
 
 		var err error
-		b, err = enc.encode(b, ctx, v.Index(i))
+		b, err = enc.encode(b, ctx, e)
+		if err != nil {
+			return nil, prependErrorPath(err, "["+strconv.Itoa(i)+"]")
+		}
+
+		b, err = enc.flush(b)
 		if err != nil {
 			return nil, err
 		}
@@ -1061,45 +2030,54 @@ func (enc *Encoder) encodeSliceAsArrayTable(b []byte, ctx encoderCtx, v reflect.
 	return b, nil
 }
 
+// Elements wrapped in Commented with comments make the array multiline, so
+// that the comments can be written next to them.
 func (enc *Encoder) encodeSliceAsArray(b []byte, ctx encoderCtx, v reflect.Value) ([]byte, error) {
-	multiline := ctx.options.multiline || enc.arraysMultiline
-	separator := ", "
+	multiline := ctx.options.multiline || enc.arraysMultiline || hasCommentedElements(v)
 
 	b = append(b, '[')
 
 	subCtx := ctx
-	subCtx.options = valueOptions{}
+	subCtx.options = valueOptions{
+		base:       ctx.options.base,
+		separators: ctx.options.separators,
+		exponent:   ctx.options.exponent,
+	}
 
 	if multiline {
-		separator = ",\n"
-
 		b = append(b, '\n')
 
 		subCtx.indent++
 	}
 
 	var err error
-	first := true
 
 	for i := 0; i < v.Len(); i++ {
-		if first {
-			first = false
-		} else {
-			b = append(b, separator...)
-		}
+		var options valueOptions
+		e := unwrapCommented(v.Index(i), &options)
 
 		if multiline {
+			b = enc.encodeComment(subCtx.indent, options.comment, b)
 			b = enc.indent(subCtx.indent, b)
+		} else if i > 0 {
+			b = append(b, ", "...)
 		}
 
-		b, err = enc.encode(b, subCtx, v.Index(i))
+		b, err = enc.encode(b, subCtx, e)
 		if err != nil {
-			return nil, err
+			return nil, prependErrorPath(err, "["+strconv.Itoa(i)+"]")
+		}
+
+		if multiline {
+			if i < v.Len()-1 {
+				b = append(b, ',')
+			}
+			b = enc.encodeTrailingComment(options.trailingComment(), b)
+			b = append(b, '\n')
 		}
 	}
 
 	if multiline {
-		b = append(b, '\n')
 		b = enc.indent(ctx.indent, b)
 	}
 
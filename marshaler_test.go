@@ -3,9 +3,12 @@ package toml_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -588,12 +591,12 @@ foo = 42
 		},
 		{
 			desc: "invalid map key",
-			v:    map[int]interface{}{1: "a"},
+			v:    map[float64]interface{}{1: "a"},
 			err:  true,
 		},
 		{
 			desc:     "invalid map key but empty",
-			v:        map[int]interface{}{},
+			v:        map[float64]interface{}{},
 			expected: "",
 		},
 		{
@@ -922,6 +925,171 @@ func TestMarshalTextMarshaler(t *testing.T) {
 	assert.Equal(t, "a = '::2'\n", string(r))
 }
 
+type tomlDuration time.Duration
+
+func (d tomlDuration) MarshalTOML() (interface{}, error) {
+	return int64(time.Duration(d) / time.Second), nil
+}
+
+type ipSet map[string]bool
+
+func (s ipSet) MarshalTOML() (interface{}, error) {
+	ips := make([]string, 0, len(s))
+	for ip := range s {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	return ips, nil
+}
+
+type pluginConfig struct {
+	kind string
+	args map[string]string
+}
+
+var errPluginWithoutKind = errors.New("plugin without kind")
+
+func (p *pluginConfig) MarshalTOML() (interface{}, error) {
+	if p.kind == "" {
+		return nil, errPluginWithoutKind
+	}
+	m := map[string]interface{}{"kind": p.kind}
+	for k, v := range p.args {
+		m[k] = v
+	}
+	return m, nil
+}
+
+type optionalString string
+
+func (s optionalString) MarshalTOML() (interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+type textAndTOMLMarshaler struct{}
+
+func (textAndTOMLMarshaler) MarshalText() ([]byte, error) {
+	return []byte("text"), nil
+}
+
+func (textAndTOMLMarshaler) MarshalTOML() (interface{}, error) {
+	return toml.LocalDate{Year: 1979, Month: 5, Day: 27}, nil
+}
+
+func TestMarshalMarshaler(t *testing.T) {
+	examples := []struct {
+		desc     string
+		v        interface{}
+		expected string
+	}{
+		{
+			desc: "integer",
+			v: struct {
+				Timeout tomlDuration
+			}{Timeout: tomlDuration(90 * time.Second)},
+			expected: "Timeout = 90\n",
+		},
+		{
+			desc: "array",
+			v: map[string]interface{}{
+				"allowed": ipSet{"10.0.0.2": true, "10.0.0.1": true},
+			},
+			expected: "allowed = ['10.0.0.1', '10.0.0.2']\n",
+		},
+		{
+			desc: "table",
+			v: &struct {
+				Name   string
+				Plugin pluginConfig
+			}{Name: "x", Plugin: pluginConfig{kind: "s3", args: map[string]string{"bucket": "b"}}},
+			expected: `Name = 'x'
+
+[Plugin]
+bucket = 'b'
+kind = 's3'
+`,
+		},
+		{
+			desc: "array table",
+			v: struct {
+				Plugins []*pluginConfig
+			}{Plugins: []*pluginConfig{{kind: "a"}, {kind: "b"}}},
+			expected: `[[Plugins]]
+kind = 'a'
+
+[[Plugins]]
+kind = 'b'
+`,
+		},
+		{
+			desc: "array of values",
+			v: struct {
+				Timeouts []tomlDuration
+			}{Timeouts: []tomlDuration{tomlDuration(time.Second), tomlDuration(time.Minute)}},
+			expected: "Timeouts = [1, 60]\n",
+		},
+		{
+			desc: "mixed interface array",
+			v: map[string]interface{}{
+				"a": []interface{}{1, tomlDuration(time.Minute), "x"},
+			},
+			expected: "a = [1, 60, 'x']\n",
+		},
+		{
+			desc: "inline table",
+			v: struct {
+				Plugin *pluginConfig `toml:",inline"`
+			}{Plugin: &pluginConfig{kind: "s3"}},
+			expected: "Plugin = {kind = 's3'}\n",
+		},
+		{
+			desc: "nil omits the value",
+			v: struct {
+				A optionalString
+				B optionalString
+			}{B: "b"},
+			expected: "B = 'b'\n",
+		},
+		{
+			desc: "precedence over TextMarshaler",
+			v: map[string]textAndTOMLMarshaler{
+				"date": {},
+			},
+			expected: "date = 1979-05-27\n",
+		},
+		{
+			desc:     "root",
+			v:        &pluginConfig{kind: "s3"},
+			expected: "kind = 's3'\n",
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			b, err := toml.Marshal(e.v)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, string(b))
+		})
+	}
+}
+
+func TestMarshalMarshalerErrors(t *testing.T) {
+	_, err := toml.Marshal(map[string]interface{}{"a": &pluginConfig{}})
+	require.EqualError(t, err, `toml: error marshalling *toml_test.pluginConfig: plugin without kind (key a, field ["a"])`)
+	require.ErrorIs(t, err, errPluginWithoutKind)
+
+	_, err = toml.Marshal(struct{ A []*pluginConfig }{A: []*pluginConfig{{kind: "a"}, {}}})
+	require.EqualError(t, err, "toml: error marshalling *toml_test.pluginConfig: plugin without kind (key A, field A[1])")
+	require.ErrorIs(t, err, errPluginWithoutKind)
+
+	_, err = toml.Marshal(tomlDuration(time.Second))
+	require.EqualError(t, err, "toml: type int64 implementing the Marshaler interface must marshal to a table when it is the root element")
+}
+
 type brokenWriter struct{}
 
 func (b *brokenWriter) Write([]byte) (int, error) {
@@ -935,6 +1103,86 @@ func TestEncodeToBrokenWriter(t *testing.T) {
 	require.Error(t, err)
 }
 
+type recordingWriter struct {
+	writes []string
+	// Number of writes after which the writer fails. Zero to never fail.
+	failAfter int
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.failAfter > 0 && len(w.writes) >= w.failAfter {
+		return 0, fmt.Errorf("dead")
+	}
+	w.writes = append(w.writes, string(b))
+	return len(b), nil
+}
+
+func TestEncoderSetStreaming(t *testing.T) {
+	type item struct {
+		Name string
+		Tags map[string]string
+	}
+	doc := struct {
+		Title string
+		Items []item
+		Other map[string]int
+	}{
+		Title: "example",
+		Items: []item{
+			{Name: "a", Tags: map[string]string{"x": "1"}},
+			{Name: "b"},
+			{Name: "c", Tags: map[string]string{"y": "2"}},
+		},
+		Other: map[string]int{"z": 3},
+	}
+
+	expected, err := toml.Marshal(doc)
+	require.NoError(t, err)
+
+	w := &recordingWriter{}
+	err = toml.NewEncoder(w).SetStreaming(true).Encode(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), strings.Join(w.writes, ""))
+	assert.Equal(t, []string{
+		"Title = 'example'\n\n[[Items]]\nName = 'a'\n\n[Items.Tags]\nx = '1'\n",
+		"\n[[Items]]\nName = 'b'\n",
+		"\n[[Items]]\nName = 'c'\n\n[Items.Tags]\ny = '2'\n",
+		"\n[Other]\nz = 3\n",
+	}, w.writes)
+}
+
+func TestEncoderSetStreamingWriteError(t *testing.T) {
+	items := make([]map[string]int, 100)
+	for i := range items {
+		items[i] = map[string]int{"value": i}
+	}
+
+	w := &recordingWriter{failAfter: 2}
+	err := toml.NewEncoder(w).SetStreaming(true).Encode(map[string]interface{}{"items": items})
+	require.Error(t, err)
+	assert.Len(t, w.writes, 2)
+}
+
+func TestEncoderSetSpecVersion(t *testing.T) {
+	doc := map[string]string{"A": "bell\a esc\x1b del\x7f"}
+
+	var w strings.Builder
+	err := toml.NewEncoder(&w).Encode(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "A = \"bell\\u0007 esc\\u001B del\\u007F\"\n", w.String())
+
+	w.Reset()
+	err = toml.NewEncoder(&w).SetSpecVersion(toml.TOML11).Encode(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "A = \"bell\\x07 esc\\e del\\x7F\"\n", w.String())
+
+	var back map[string]string
+	err = toml.NewDecoder(strings.NewReader(w.String())).SetSpecVersion(toml.TOML11).Decode(&back)
+	require.NoError(t, err)
+	assert.Equal(t, doc, back)
+}
+
 func TestEncoderSetIndentSymbol(t *testing.T) {
 	var w strings.Builder
 	enc := toml.NewEncoder(&w)
@@ -971,6 +1219,518 @@ F = 0
 	assert.Equal(t, expected, w.String())
 }
 
+func TestEncoderSetDottedTables(t *testing.T) {
+	type route struct {
+		Path string
+	}
+	type http struct {
+		Port   int
+		Routes []route
+		TLS    struct{ Enabled bool }
+	}
+	type server struct {
+		Name  string
+		HTTP  http `comment:"HTTP settings"`
+		Empty struct{}
+	}
+	type config struct {
+		Title  string
+		Server server
+		Tags   map[string]int `toml:",omitempty"`
+	}
+
+	c := config{
+		Title: "example",
+		Server: server{
+			Name: "web",
+			HTTP: http{Port: 80, Routes: []route{{Path: "/a"}, {Path: "/b"}}},
+		},
+	}
+
+	examples := []struct {
+		depth    int
+		expected string
+	}{
+		{
+			depth: 1,
+			expected: `Title = 'example'
+Server.Name = 'web'
+# HTTP settings
+Server.HTTP.Port = 80
+Server.HTTP.TLS.Enabled = false
+Server.Empty = {}
+
+[[Server.HTTP.Routes]]
+Path = '/a'
+
+[[Server.HTTP.Routes]]
+Path = '/b'
+`,
+		},
+		{
+			depth: 2,
+			expected: `Title = 'example'
+
+[Server]
+Name = 'web'
+# HTTP settings
+HTTP.Port = 80
+HTTP.TLS.Enabled = false
+Empty = {}
+
+[[Server.HTTP.Routes]]
+Path = '/a'
+
+[[Server.HTTP.Routes]]
+Path = '/b'
+`,
+		},
+		{
+			depth: 3,
+			expected: `Title = 'example'
+
+[Server]
+Name = 'web'
+
+# HTTP settings
+[Server.HTTP]
+Port = 80
+TLS.Enabled = false
+
+[[Server.HTTP.Routes]]
+Path = '/a'
+
+[[Server.HTTP.Routes]]
+Path = '/b'
+
+[Server.Empty]
+`,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(fmt.Sprint(e.depth), func(t *testing.T) {
+			var w strings.Builder
+			err := toml.NewEncoder(&w).SetDottedTables(e.depth).Encode(c)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, w.String())
+
+			var back config
+			err = toml.Unmarshal([]byte(w.String()), &back)
+			require.NoError(t, err)
+			assert.Equal(t, c, back)
+		})
+	}
+}
+
+func TestEncoderDottedTag(t *testing.T) {
+	type doc struct {
+		Name   string
+		Limits struct {
+			CPU    int `toml:"cpu"`
+			Memory struct {
+				Max string `toml:"max"`
+			} `toml:"memory"`
+		} `toml:"limits,dotted"`
+		Env map[string]string `toml:"env,dotted,commented"`
+		Sub struct {
+			A int
+		}
+	}
+
+	var d doc
+	d.Name = "job"
+	d.Limits.CPU = 2
+	d.Limits.Memory.Max = "1G"
+	d.Env = map[string]string{"HOME": "/root", "A": "b"}
+	d.Sub.A = 1
+
+	b, err := toml.Marshal(d)
+	require.NoError(t, err)
+
+	expected := `Name = 'job'
+limits.cpu = 2
+limits.memory.max = '1G'
+# env.A = 'b'
+# env.HOME = '/root'
+
+[Sub]
+A = 1
+`
+	assert.Equal(t, expected, string(b))
+}
+
+func TestMarshalNumberFormats(t *testing.T) {
+	type doc struct {
+		Mode     uint32  `toml:"mode,octal"`
+		Mask     int     `toml:"mask,binary"`
+		Color    int     `toml:"color,hex"`
+		Key      uint64  `toml:"key,hex,separators"`
+		Flags    []uint8 `toml:"flags,binary"`
+		Big      int64   `toml:"big,separators"`
+		Negative int     `toml:"negative,separators"`
+		Min      int64   `toml:"min"`
+		Float    float64 `toml:"float,separators"`
+		Large    float64 `toml:"large,exponent"`
+		Small    float32 `toml:"small,exponent"`
+		Short    float64 `toml:"short,exponent"`
+		Plain    int     `toml:"plain"`
+	}
+
+	d := doc{
+		Mode:     0o755,
+		Mask:     10,
+		Color:    0xff00aa,
+		Key:      0xdeadbeef,
+		Flags:    []uint8{1, 6},
+		Big:      1234567,
+		Negative: -1000,
+		Min:      math.MinInt64,
+		Float:    -12345.678,
+		Large:    1e300,
+		Small:    1.5e-7,
+		Short:    12.5,
+		Plain:    1000,
+	}
+
+	b, err := toml.Marshal(d)
+	require.NoError(t, err)
+
+	expected := `mode = 0o755
+mask = 0b1010
+color = 0xff00aa
+key = 0xdead_beef
+flags = [0b1, 0b110]
+big = 1_234_567
+negative = -1_000
+min = -9223372036854775808
+float = -12_345.678
+large = 1e300
+small = 1.5e-7
+short = 12.5
+plain = 1000
+`
+	assert.Equal(t, expected, string(b))
+
+	var back doc
+	err = toml.Unmarshal(b, &back)
+	require.NoError(t, err)
+	assert.Equal(t, d, back)
+}
+
+func TestMarshalNumberFormatsNegative(t *testing.T) {
+	_, err := toml.Marshal(struct {
+		A int `toml:",hex"`
+	}{A: -1})
+	require.EqualError(t, err, "toml: cannot encode negative integer -1 in base 16 (key A, field A)")
+}
+
+func TestEncoderSetDigitSeparators(t *testing.T) {
+	var w strings.Builder
+	err := toml.NewEncoder(&w).SetDigitSeparators(true).Encode(map[string]interface{}{
+		"a": 100,
+		"b": 1000,
+		"c": uint64(1e12),
+		"d": 1234.5,
+		"e": []int64{-123456},
+	})
+	require.NoError(t, err)
+
+	expected := `a = 100
+b = 1_000
+c = 1_000_000_000_000
+d = 1_234.5
+e = [-123_456]
+`
+	assert.Equal(t, expected, w.String())
+}
+
+func TestEncoderSetFloatExponent(t *testing.T) {
+	var w strings.Builder
+	err := toml.NewEncoder(&w).SetFloatExponent(true).Encode(map[string]interface{}{
+		"a": 1.0,
+		"b": 1e21,
+		"c": -2.5e-10,
+		"d": 123456.789,
+		"e": float32(3e38),
+		"f": math.Inf(1),
+	})
+	require.NoError(t, err)
+
+	expected := `a = 1.0
+b = 1e21
+c = -2.5e-10
+d = 123456.789
+e = 3e38
+f = inf
+`
+	assert.Equal(t, expected, w.String())
+
+	var back map[string]float64
+	err = toml.Unmarshal([]byte(w.String()), &back)
+	require.NoError(t, err)
+	assert.Equal(t, 1e21, back["b"])
+	assert.Equal(t, -2.5e-10, back["c"])
+}
+
+func TestMarshalIntegerAndBoolMapKeys(t *testing.T) {
+	type port struct {
+		Protocol string
+	}
+	type config struct {
+		Ports   map[uint16]port
+		Weights map[int]float64
+		Enabled map[bool]string
+	}
+
+	c := config{
+		Ports:   map[uint16]port{8080: {Protocol: "http"}, 443: {Protocol: "https"}},
+		Weights: map[int]float64{10: 1, -1: 0.5, 9: 2},
+		Enabled: map[bool]string{true: "on", false: "off"},
+	}
+
+	b, err := toml.Marshal(c)
+	require.NoError(t, err)
+
+	expected := `[Ports]
+[Ports.443]
+Protocol = 'https'
+
+[Ports.8080]
+Protocol = 'http'
+
+[Weights]
+-1 = 0.5
+9 = 2.0
+10 = 1.0
+
+[Enabled]
+false = 'off'
+true = 'on'
+`
+	assert.Equal(t, expected, string(b))
+
+	var back config
+	err = toml.Unmarshal(b, &back)
+	require.NoError(t, err)
+	assert.Equal(t, c, back)
+}
+
+type cycleNode struct {
+	Name string
+	Next *cycleNode
+}
+
+type CycleEmbedded struct {
+	*CycleEmbedded
+	A int
+}
+
+func TestMarshalCycles(t *testing.T) {
+	list := &cycleNode{Name: "a", Next: &cycleNode{Name: "b"}}
+	list.Next.Next = list
+
+	m := map[string]interface{}{"a": 1}
+	m["self"] = map[string]interface{}{"m": m}
+
+	s := []interface{}{1, nil}
+	s[1] = s
+
+	e := &CycleEmbedded{A: 1}
+	e.CycleEmbedded = e
+
+	examples := []struct {
+		desc string
+		v    interface{}
+		path string
+	}{
+		{desc: "struct pointers", v: list, path: "Next.Next"},
+		{desc: "maps", v: m, path: `["self"]["m"]`},
+		{desc: "slices", v: map[string]interface{}{"s": s}, path: `["s"][1]`},
+		{desc: "embedded pointers", v: e, path: "CycleEmbedded"},
+		{desc: "array tables", v: map[string]interface{}{"t": []interface{}{m}}, path: `["t"][0]["self"]["m"]`},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			_, err := toml.Marshal(e.v)
+			var cerr *toml.CycleError
+			require.True(t, errors.As(err, &cerr), "%v", err)
+			assert.Equal(t, e.path, cerr.Path)
+		})
+	}
+
+	t.Run("dotted tables", func(t *testing.T) {
+		var w strings.Builder
+		err := toml.NewEncoder(&w).SetDottedTables(1).Encode(m)
+		var cerr *toml.CycleError
+		require.True(t, errors.As(err, &cerr), "%v", err)
+		assert.Equal(t, `["self"]["m"]`, cerr.Path)
+	})
+}
+
+func TestMarshalSharedValuesAreNotCycles(t *testing.T) {
+	shared := &cycleNode{Name: "shared"}
+	v := struct {
+		A *cycleNode
+		B *cycleNode
+		C []*cycleNode
+	}{A: shared, B: shared, C: []*cycleNode{shared, shared}}
+
+	b, err := toml.Marshal(v)
+	require.NoError(t, err)
+
+	expected := `[A]
+Name = 'shared'
+
+[B]
+Name = 'shared'
+
+[[C]]
+Name = 'shared'
+
+[[C]]
+Name = 'shared'
+`
+	assert.Equal(t, expected, string(b))
+}
+
+func TestEncoderSetMaxDepth(t *testing.T) {
+	v := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": []interface{}{1, 2},
+		},
+	}
+
+	var w strings.Builder
+	err := toml.NewEncoder(&w).SetMaxDepth(3).Encode(v)
+	require.NoError(t, err)
+	assert.Equal(t, "[a]\nb = [1, 2]\n", w.String())
+
+	w.Reset()
+	err = toml.NewEncoder(&w).SetMaxDepth(2).Encode(v)
+	require.EqualError(t, err, `toml: maximum depth of 2 exceeded (key a.b, field ["a"]["b"])`)
+}
+
+var errTextMarshaler = errors.New("cannot be marshaled")
+
+type failingTextMarshaler struct{}
+
+func (failingTextMarshaler) MarshalText() ([]byte, error) {
+	return nil, errTextMarshaler
+}
+
+func TestEncodeError(t *testing.T) {
+	type server struct {
+		Name string
+		Port interface{}
+	}
+
+	list := &cycleNode{Name: "a"}
+	list.Next = list
+
+	var config struct {
+		Storage struct {
+			Backend struct {
+				Plugin *pluginConfig `toml:"plugin"`
+			} `toml:"backend"`
+		} `toml:"storage"`
+	}
+	config.Storage.Backend.Plugin = &pluginConfig{}
+
+	examples := []struct {
+		desc  string
+		v     interface{}
+		err   string
+		key   toml.Key
+		field string
+		typ   reflect.Type
+	}{
+		{
+			desc: "unsupported type",
+			v: struct{ Servers []server }{Servers: []server{
+				{Name: "a", Port: 80},
+				{Name: "b", Port: make(chan int)},
+			}},
+			err:   "toml: cannot encode value of type chan (key Servers.Port, field Servers[1].Port)",
+			key:   toml.Key{"Servers", "Port"},
+			field: "Servers[1].Port",
+			typ:   reflect.TypeOf(make(chan int)),
+		},
+		{
+			desc:  "text marshaler",
+			v:     map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1, failingTextMarshaler{}}}},
+			err:   `toml: error marshalling toml_test.failingTextMarshaler to text: cannot be marshaled (key a.b, field ["a"]["b"][1])`,
+			key:   toml.Key{"a", "b"},
+			field: `["a"]["b"][1]`,
+			typ:   reflect.TypeOf(failingTextMarshaler{}),
+		},
+		{
+			desc:  "map key",
+			v:     map[string]interface{}{"m": map[float64]int{1.5: 1}},
+			err:   `toml: type float64 is not supported as a map key (key m, field ["m"])`,
+			key:   toml.Key{"m"},
+			field: `["m"]`,
+			typ:   reflect.TypeOf(1.5),
+		},
+		{
+			desc:  "cycle",
+			v:     list,
+			err:   "toml: value of type *toml_test.cycleNode contains itself (key Next, field Next)",
+			key:   toml.Key{"Next"},
+			field: "Next",
+			typ:   reflect.TypeOf(list),
+		},
+		{
+			desc:  "marshaler",
+			v:     config,
+			err:   "toml: error marshalling *toml_test.pluginConfig: plugin without kind (key storage.backend.plugin, field Storage.Backend.Plugin)",
+			key:   toml.Key{"storage", "backend", "plugin"},
+			field: "Storage.Backend.Plugin",
+			typ:   reflect.TypeOf(&pluginConfig{}),
+		},
+		{
+			desc: "root",
+			v:    tomlDuration(time.Second),
+			err:  "toml: type int64 implementing the Marshaler interface must marshal to a table when it is the root element",
+			typ:  reflect.TypeOf(int64(0)),
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			_, err := toml.Marshal(e.v)
+			var eerr *toml.EncodeError
+			require.True(t, errors.As(err, &eerr), "%v", err)
+			assert.Equal(t, e.err, eerr.Error())
+			assert.Equal(t, e.key, eerr.Key())
+			assert.Equal(t, e.field, eerr.Field())
+			assert.Equal(t, e.typ, eerr.Type())
+		})
+	}
+}
+
+func TestEncodeErrorUnwrap(t *testing.T) {
+	_, err := toml.Marshal(struct{ A failingTextMarshaler }{})
+	assert.True(t, errors.Is(err, errTextMarshaler))
+
+	_, err = toml.Marshal(map[failingTextMarshaler]int{{}: 1})
+	assert.True(t, errors.Is(err, errTextMarshaler))
+
+	_, err = toml.Marshal(struct{ A struct{ B *pluginConfig } }{A: struct{ B *pluginConfig }{B: &pluginConfig{}}})
+	assert.True(t, errors.Is(err, errPluginWithoutKind))
+
+	list := &cycleNode{Name: "a"}
+	list.Next = list
+	_, err = toml.Marshal(list)
+	var cerr *toml.CycleError
+	require.True(t, errors.As(err, &cerr))
+	assert.Equal(t, "Next", cerr.Path)
+}
+
 func TestEncoderOmitempty(t *testing.T) {
 	type doc struct {
 		String  string            `toml:",omitempty,multiline"`
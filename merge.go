@@ -0,0 +1,247 @@
+package toml
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ArrayMerge is the policy used by a Merger to combine an array with the array
+// of the same key in a later document.
+type ArrayMerge int
+
+const (
+	// ReplaceArrays replaces the array with the one of the later document.
+	ReplaceArrays ArrayMerge = iota
+	// AppendArrays appends the elements of the array of the later document.
+	AppendArrays
+)
+
+// Merger combines several TOML documents into one, each document overriding
+// the ones merged before it. It is typically used to layer configuration
+// files, for example defaults.toml, then site.toml, then local.toml.
+//
+// Tables are merged recursively. Other values of a later document replace the
+// ones of earlier documents, except arrays and array tables, which are
+// combined according to SetArrayMerge and MergeArrayTablesBy.
+//
+// The result is a document decoded into a map[string]interface{}, as done by
+// Unmarshal. Merger keeps track of the document that set each key, so that
+// errors found in the result can name the right document.
+type Merger struct {
+	arrays ArrayMerge
+
+	// Key identifying the elements of array tables, indexed by the
+	// metadataIndex of the array table.
+	arrayKeys map[string]string
+
+	value map[string]interface{}
+
+	// Name of the document that set each key, as a tree following the keys.
+	sources *sourceNode
+}
+
+// sourceNode is the name of the document that set a key, and the nodes of the
+// keys it contains. Replacing a table forgets about its keys by dropping the
+// children of its node.
+type sourceNode struct {
+	name     string
+	children map[string]*sourceNode
+}
+
+func (n *sourceNode) child(k string) *sourceNode {
+	c, ok := n.children[k]
+	if !ok {
+		if n.children == nil {
+			n.children = map[string]*sourceNode{}
+		}
+		c = &sourceNode{}
+		n.children[k] = c
+	}
+	return c
+}
+
+// NewMerger creates an empty Merger.
+func NewMerger() *Merger {
+	return &Merger{
+		arrayKeys: map[string]string{},
+		value:     map[string]interface{}{},
+		sources:   &sourceNode{},
+	}
+}
+
+// SetArrayMerge sets the policy used to combine arrays. Defaults to
+// ReplaceArrays. It also applies to array tables that are not merged by key.
+func (m *Merger) SetArrayMerge(p ArrayMerge) *Merger {
+	m.arrays = p
+	return m
+}
+
+// MergeArrayTablesBy makes the elements of the array table key be identified
+// by the value of their field key. When a later document contains an element
+// with the same field value as an existing element, both tables are merged.
+// Otherwise, the element is appended.
+//
+// key is the full key of the array table. Keys of array tables nested in other
+// array tables do not contain indexes: for example, the [[servers.ports]]
+// array table is identified by Key{"servers", "ports"}.
+func (m *Merger) MergeArrayTablesBy(key Key, field string) *Merger {
+	m.arrayKeys[metadataIndex(key)] = field
+	return m
+}
+
+// Merge decodes document and merges it on top of the documents merged so far.
+// name identifies the document, for example by its file name. It is returned
+// by Source and used in the DecodeError returned when the document cannot be
+// decoded.
+func (m *Merger) Merge(name string, document []byte) error {
+	var doc map[string]interface{}
+	err := Unmarshal(document, &doc)
+	if err != nil {
+		var derr *DecodeError
+		if errors.As(err, &derr) {
+			derr.source = name
+		}
+		return err
+	}
+
+	m.mergeTable(m.value, doc, nil, name, m.sources)
+
+	return nil
+}
+
+// Decode decodes the result of the merge into v, as Unmarshal would decode a
+// document containing it. When a value cannot be decoded, the returned
+// DecodeError names the document that set it. Its position and context refer
+// to the result of the merge encoded as a document, not to the document that
+// set the value.
+func (m *Merger) Decode(v interface{}) error {
+	b, err := Marshal(m.value)
+	if err != nil {
+		return err
+	}
+
+	err = Unmarshal(b, v)
+	var derr *DecodeError
+	if errors.As(err, &derr) {
+		derr.source = m.sourceOf(derr.key)
+	}
+	return err
+}
+
+// Value returns the result of the merge. It is owned by the Merger, and
+// modified by further calls to Merge.
+func (m *Merger) Value() map[string]interface{} {
+	return m.value
+}
+
+// Source returns the name of the last document that set the given key. For a
+// table, it is the last document that defined a key inside it. Keys of the
+// tables contained in arrays are not tracked individually: they are reported
+// as set by the document that last modified the array.
+func (m *Merger) Source(key ...string) (string, bool) {
+	n := m.sources
+	for _, k := range key {
+		c, ok := n.children[k]
+		if !ok {
+			return "", false
+		}
+		n = c
+	}
+	return n.name, n != m.sources
+}
+
+// sourceOf returns the name of the document that set the innermost tracked key
+// containing key.
+func (m *Merger) sourceOf(key Key) string {
+	n := m.sources
+	for _, k := range key {
+		c, ok := n.children[k]
+		if !ok {
+			break
+		}
+		n = c
+	}
+	return n.name
+}
+
+// mergeTable merges src into dst. sources is the node of key, nil inside
+// arrays, where keys are not tracked.
+func (m *Merger) mergeTable(dst, src map[string]interface{}, key Key, name string, sources *sourceNode) {
+	for k, sv := range src {
+		kk := appendKey(key, k)
+		var node *sourceNode
+		if sources != nil {
+			node = sources.child(k)
+			node.name = name
+		}
+
+		dv, ok := dst[k]
+		if ok {
+			switch s := sv.(type) {
+			case map[string]interface{}:
+				if d, ok := dv.(map[string]interface{}); ok {
+					m.mergeTable(d, s, kk, name, node)
+					continue
+				}
+			case []interface{}:
+				if d, ok := dv.([]interface{}); ok {
+					dst[k] = m.mergeArray(d, s, kk, name)
+					continue
+				}
+			}
+		}
+
+		dst[k] = sv
+		if node != nil {
+			node.children = nil
+			recordSources(node, sv, name)
+		}
+	}
+}
+
+func (m *Merger) mergeArray(dst, src []interface{}, key Key, name string) []interface{} {
+	field, ok := m.arrayKeys[metadataIndex(key)]
+	if !ok {
+		if m.arrays == AppendArrays {
+			return append(dst, src...)
+		}
+		return src
+	}
+
+	for _, se := range src {
+		s, ok := se.(map[string]interface{})
+		if !ok {
+			dst = append(dst, se)
+			continue
+		}
+
+		merged := false
+		if id, ok := s[field]; ok {
+			for _, de := range dst {
+				d, ok := de.(map[string]interface{})
+				if ok && reflect.DeepEqual(d[field], id) {
+					m.mergeTable(d, s, key, name, nil)
+					merged = true
+					break
+				}
+			}
+		}
+		if !merged {
+			dst = append(dst, s)
+		}
+	}
+
+	return dst
+}
+
+// recordSources records that v, the value of the key of node, has been set by
+// the document name.
+func recordSources(node *sourceNode, v interface{}, name string) {
+	node.name = name
+
+	if t, ok := v.(map[string]interface{}); ok {
+		for k, c := range t {
+			recordSources(node.child(k), c, name)
+		}
+	}
+}
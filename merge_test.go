@@ -0,0 +1,209 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerger(t *testing.T) {
+	defaults := `
+name = "app"
+tags = ["a"]
+
+[server]
+host = "localhost"
+port = 80
+tls = { enabled = false, cert = "" }
+
+[[users]]
+name = "root"
+shell = "sh"
+`
+
+	site := `
+tags = ["b"]
+
+[server]
+port = 8080
+tls.enabled = true
+
+[[users]]
+name = "root"
+shell = "bash"
+
+[[users]]
+name = "guest"
+`
+
+	m := toml.NewMerger().MergeArrayTablesBy(toml.Key{"users"}, "name")
+	require.NoError(t, m.Merge("defaults.toml", []byte(defaults)))
+	require.NoError(t, m.Merge("site.toml", []byte(site)))
+
+	assert.Equal(t, map[string]interface{}{
+		"name": "app",
+		"tags": []interface{}{"b"},
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": int64(8080),
+			"tls": map[string]interface{}{
+				"enabled": true,
+				"cert":    "",
+			},
+		},
+		"users": []interface{}{
+			map[string]interface{}{"name": "root", "shell": "bash"},
+			map[string]interface{}{"name": "guest"},
+		},
+	}, m.Value())
+
+	sources := []struct {
+		key    []string
+		source string
+	}{
+		{key: []string{"name"}, source: "defaults.toml"},
+		{key: []string{"tags"}, source: "site.toml"},
+		{key: []string{"server"}, source: "site.toml"},
+		{key: []string{"server", "host"}, source: "defaults.toml"},
+		{key: []string{"server", "port"}, source: "site.toml"},
+		{key: []string{"server", "tls", "cert"}, source: "defaults.toml"},
+		{key: []string{"server", "tls", "enabled"}, source: "site.toml"},
+		{key: []string{"users"}, source: "site.toml"},
+	}
+	for _, s := range sources {
+		source, ok := m.Source(s.key...)
+		assert.True(t, ok, s.key)
+		assert.Equal(t, s.source, source, s.key)
+	}
+
+	_, ok := m.Source("server", "missing")
+	assert.False(t, ok)
+	_, ok = m.Source("users", "name")
+	assert.False(t, ok)
+}
+
+func TestMergerArrays(t *testing.T) {
+	first := `
+a = [1]
+[[t]]
+x = 1
+`
+	second := `
+a = [2]
+[[t]]
+x = 2
+`
+
+	m := toml.NewMerger()
+	require.NoError(t, m.Merge("first", []byte(first)))
+	require.NoError(t, m.Merge("second", []byte(second)))
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{int64(2)},
+		"t": []interface{}{map[string]interface{}{"x": int64(2)}},
+	}, m.Value())
+
+	m = toml.NewMerger().SetArrayMerge(toml.AppendArrays)
+	require.NoError(t, m.Merge("first", []byte(first)))
+	require.NoError(t, m.Merge("second", []byte(second)))
+	assert.Equal(t, map[string]interface{}{
+		"a": []interface{}{int64(1), int64(2)},
+		"t": []interface{}{
+			map[string]interface{}{"x": int64(1)},
+			map[string]interface{}{"x": int64(2)},
+		},
+	}, m.Value())
+}
+
+func TestMergerOverrideType(t *testing.T) {
+	m := toml.NewMerger()
+	require.NoError(t, m.Merge("first", []byte("a.b = 1\nc = 1")))
+	require.NoError(t, m.Merge("second", []byte("a = 2\nc.d = 2")))
+	assert.Equal(t, map[string]interface{}{
+		"a": int64(2),
+		"c": map[string]interface{}{"d": int64(2)},
+	}, m.Value())
+
+	_, ok := m.Source("a", "b")
+	assert.False(t, ok)
+	source, ok := m.Source("c", "d")
+	assert.True(t, ok)
+	assert.Equal(t, "second", source)
+}
+
+func TestMergerError(t *testing.T) {
+	m := toml.NewMerger()
+	require.NoError(t, m.Merge("first.toml", []byte("a = 1")))
+
+	err := m.Merge("second.toml", []byte("a = 1\nb = "))
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+	assert.Equal(t, "second.toml", derr.Source())
+	assert.Equal(t, "toml: second.toml: expected value, not eof", derr.Error())
+
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, m.Value())
+}
+
+func TestMergerDecode(t *testing.T) {
+	type server struct {
+		Host string
+		Port uint16
+	}
+	type config struct {
+		Name   string
+		Server server
+	}
+
+	m := toml.NewMerger()
+	require.NoError(t, m.Merge("defaults.toml", []byte("name = \"app\"\n[server]\nhost = \"localhost\"\nport = 80")))
+	require.NoError(t, m.Merge("local.toml", []byte("server.port = 8080")))
+
+	var cfg config
+	require.NoError(t, m.Decode(&cfg))
+	assert.Equal(t, config{Name: "app", Server: server{Host: "localhost", Port: 8080}}, cfg)
+
+	require.NoError(t, m.Merge("broken.toml", []byte("server.port = 99999")))
+	err := m.Decode(&cfg)
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+	assert.Equal(t, "broken.toml", derr.Source())
+	assert.Equal(t, toml.Key{"server", "port"}, derr.Key())
+	assert.Equal(t, toml.CodeOverflow, derr.Code())
+}
+
+func ExampleMerger() {
+	defaults := `
+[server]
+host = "localhost"
+port = 80
+`
+	local := `
+[server]
+port = 99999
+`
+
+	m := toml.NewMerger()
+	for _, doc := range []struct{ name, content string }{
+		{"defaults.toml", defaults},
+		{"local.toml", local},
+	} {
+		err := m.Merge(doc.name, []byte(doc.content))
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	server := m.Value()["server"].(map[string]interface{})
+	fmt.Println("host:", server["host"])
+	if server["port"].(int64) > 65535 {
+		source, _ := m.Source("server", "port")
+		fmt.Printf("%s: port out of range\n", source)
+	}
+
+	// Output:
+	// host: localhost
+	// local.toml: port out of range
+}
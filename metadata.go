@@ -0,0 +1,136 @@
+package toml
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Metadata describes the keys of a document decoded by a Decoder. See
+// Decoder.RecordMetadata.
+type Metadata struct {
+	keys []KeyMetadata
+
+	// Index in keys of the first key defining each key path, including the
+	// tables implicitly defined by dotted keys.
+	defined map[string]int
+}
+
+// KeyMetadata describes a key of a decoded document.
+type KeyMetadata struct {
+	// Full path of the key, including the table it belongs to.
+	Key Key
+
+	// Position of the first byte of the key in the document.
+	Position Position
+
+	// Location of the value of a key-value in the document. Zero for tables
+	// and array tables.
+	Value Shape
+
+	// Decoded is true when the key was stored in the target value, and false
+	// when the target has no field or map for it.
+	Decoded bool
+}
+
+// Keys returns all the tables, array tables, and key-values of the document,
+// in the order they appear. Array tables are listed once per element.
+//
+// The returned slice must not be modified.
+func (m *Metadata) Keys() []KeyMetadata {
+	return m.keys
+}
+
+// IsDefined returns true if the key is defined in the document, either
+// explicitly or as a table containing other keys. For example, after decoding
+// `a.b = 1`, both "a" and "a.b" are defined.
+func (m *Metadata) IsDefined(key ...string) bool {
+	_, ok := m.defined[metadataIndex(key)]
+	return ok
+}
+
+// Position returns the position of the first key of the document that defines
+// the given key.
+func (m *Metadata) Position(key ...string) (Position, bool) {
+	idx, ok := m.defined[metadataIndex(key)]
+	if !ok {
+		return Position{}, false
+	}
+	return m.keys[idx].Position, true
+}
+
+// ValueShape returns the location of the value of the first key-value of the
+// document with the given key. It allows to point at the offending value when
+// a decoded value fails validation.
+func (m *Metadata) ValueShape(key ...string) (Shape, bool) {
+	idx, ok := m.defined[metadataIndex(key)]
+	if !ok || len(m.keys[idx].Key) != len(key) || m.keys[idx].Value == (Shape{}) {
+		return Shape{}, false
+	}
+	return m.keys[idx].Value, true
+}
+
+// Undecoded returns the keys of the document that were not stored in the
+// target value, in the order they appear. Keys contained in an undecoded table
+// are listed as well.
+func (m *Metadata) Undecoded() []Key {
+	var keys []Key
+	for _, k := range m.keys {
+		if !k.Decoded {
+			keys = append(keys, k.Key)
+		}
+	}
+	return keys
+}
+
+func (m *Metadata) reset() {
+	m.keys = nil
+	m.defined = map[string]int{}
+}
+
+// add records a key and returns its index.
+func (m *Metadata) add(key Key, pos Position, value Shape) int {
+	idx := len(m.keys)
+	m.keys = append(m.keys, KeyMetadata{
+		Key:      key,
+		Position: pos,
+		Value:    value,
+		Decoded:  true,
+	})
+
+	for i := 1; i <= len(key); i++ {
+		k := metadataIndex(key[:i])
+		if _, ok := m.defined[k]; !ok {
+			m.defined[k] = idx
+		}
+	}
+
+	return idx
+}
+
+// undecoded marks key as not decoded, where it is recorded at the given offset
+// of the document or its value contains it. It returns false if there is no
+// such key. Keys are looked up from the most recent one, as they are marked
+// shortly after being recorded.
+func (m *Metadata) undecoded(key Key, offset int) bool {
+	for i := len(m.keys) - 1; i >= 0; i-- {
+		k := &m.keys[i]
+		at := k.Position.Offset == offset || (k.Value.Start.Offset <= offset && offset < k.Value.End.Offset)
+		if at && metadataIndex(k.Key) == metadataIndex(key) {
+			k.Decoded = false
+			return true
+		}
+	}
+	return false
+}
+
+// metadataIndex returns an unambiguous representation of a key, usable as a
+// map key.
+func metadataIndex(key []string) string {
+	var b strings.Builder
+	for _, p := range key {
+		b.WriteString(strconv.Itoa(len(p)))
+		b.WriteByte(':')
+		b.WriteString(p)
+	}
+	return b.String()
+}
@@ -0,0 +1,210 @@
+package toml_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderRecordMetadata(t *testing.T) {
+	doc := `port = 0
+name = "x"
+extra = 1
+
+[server]
+host = "localhost"
+limits = { max = 10, min = 0 }
+a.b = true
+
+[unknown]
+c = 2
+
+[[items]]
+id = 1
+[[items]]
+id = 2
+`
+
+	type config struct {
+		Port    int
+		Name    string
+		Timeout int
+		Server  struct {
+			Host   string
+			Limits struct{ Max int }
+			A      struct{ B bool }
+		}
+		Items []struct{ ID int }
+	}
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			var md toml.Metadata
+			var cfg config
+			d := toml.NewDecoder(strings.NewReader(doc)).RecordMetadata(&md)
+			if streaming {
+				d.EnableStreaming()
+			}
+			require.NoError(t, d.Decode(&cfg))
+
+			expected := []toml.KeyMetadata{
+				{Key: toml.Key{"port"}, Position: toml.Position{Line: 1, Column: 1, Offset: 0}, Value: shape(1, 8, 7, 1, 9, 8), Decoded: true},
+				{Key: toml.Key{"name"}, Position: toml.Position{Line: 2, Column: 1, Offset: 9}, Value: shape(2, 8, 16, 2, 11, 19), Decoded: true},
+				{Key: toml.Key{"extra"}, Position: toml.Position{Line: 3, Column: 1, Offset: 20}, Value: shape(3, 9, 28, 3, 10, 29), Decoded: false},
+				{Key: toml.Key{"server"}, Position: toml.Position{Line: 5, Column: 2, Offset: 32}, Decoded: true},
+				{Key: toml.Key{"server", "host"}, Position: toml.Position{Line: 6, Column: 1, Offset: 40}, Value: shape(6, 8, 47, 6, 19, 58), Decoded: true},
+				{Key: toml.Key{"server", "limits"}, Position: toml.Position{Line: 7, Column: 1, Offset: 59}, Value: shape(7, 10, 68, 7, 31, 89), Decoded: true},
+				{Key: toml.Key{"server", "limits", "max"}, Position: toml.Position{Line: 7, Column: 12, Offset: 70}, Value: shape(7, 18, 76, 7, 20, 78), Decoded: true},
+				{Key: toml.Key{"server", "limits", "min"}, Position: toml.Position{Line: 7, Column: 22, Offset: 80}, Value: shape(7, 28, 86, 7, 29, 87), Decoded: false},
+				{Key: toml.Key{"server", "a", "b"}, Position: toml.Position{Line: 8, Column: 1, Offset: 90}, Value: shape(8, 7, 96, 8, 11, 100), Decoded: true},
+				{Key: toml.Key{"unknown"}, Position: toml.Position{Line: 10, Column: 2, Offset: 103}, Decoded: false},
+				{Key: toml.Key{"unknown", "c"}, Position: toml.Position{Line: 11, Column: 1, Offset: 112}, Value: shape(11, 5, 116, 11, 6, 117), Decoded: false},
+				{Key: toml.Key{"items"}, Position: toml.Position{Line: 13, Column: 3, Offset: 121}, Decoded: true},
+				{Key: toml.Key{"items", "id"}, Position: toml.Position{Line: 14, Column: 1, Offset: 129}, Value: shape(14, 6, 134, 14, 7, 135), Decoded: true},
+				{Key: toml.Key{"items"}, Position: toml.Position{Line: 15, Column: 3, Offset: 138}, Decoded: true},
+				{Key: toml.Key{"items", "id"}, Position: toml.Position{Line: 16, Column: 1, Offset: 146}, Value: shape(16, 6, 151, 16, 7, 152), Decoded: true},
+			}
+			assert.Equal(t, expected, md.Keys())
+
+			assert.Equal(t, []toml.Key{
+				{"extra"},
+				{"server", "limits", "min"},
+				{"unknown"},
+				{"unknown", "c"},
+			}, md.Undecoded())
+
+			assert.True(t, md.IsDefined("port"))
+			assert.True(t, md.IsDefined("server", "a"))
+			assert.True(t, md.IsDefined("unknown", "c"))
+			assert.False(t, md.IsDefined("timeout"))
+			assert.False(t, md.IsDefined("server", "port"))
+
+			pos, ok := md.Position("server", "a")
+			assert.True(t, ok)
+			assert.Equal(t, toml.Position{Line: 8, Column: 1, Offset: 90}, pos)
+			_, ok = md.Position("timeout")
+			assert.False(t, ok)
+
+			value, ok := md.ValueShape("server", "limits", "max")
+			assert.True(t, ok)
+			assert.Equal(t, shape(7, 18, 76, 7, 20, 78), value)
+			_, ok = md.ValueShape("server")
+			assert.False(t, ok)
+			_, ok = md.ValueShape("server", "a")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func shape(startLine, startColumn, startOffset, endLine, endColumn, endOffset int) toml.Shape {
+	return toml.Shape{
+		Start: toml.Position{Line: startLine, Column: startColumn, Offset: startOffset},
+		End:   toml.Position{Line: endLine, Column: endColumn, Offset: endOffset},
+	}
+}
+
+func TestDecoderRecordMetadataStrict(t *testing.T) {
+	var md toml.Metadata
+	var v struct{ A int }
+	err := toml.NewDecoder(strings.NewReader("a = 1\nb = 2\n")).
+		DisallowUnknownFields().
+		RecordMetadata(&md).
+		Decode(&v)
+	require.Error(t, err)
+	assert.Equal(t, []toml.Key{{"b"}}, md.Undecoded())
+}
+
+func TestDecoderRecordMetadataCollectErrors(t *testing.T) {
+	doc := `port = 'x'
+name = 'a'
+
+[server]
+host = 1
+limits = { max = 'y', min = 1 }
+`
+
+	var md toml.Metadata
+	var v struct {
+		Port   int
+		Name   string
+		Server struct {
+			Host   string
+			Limits struct{ Max, Min int }
+		}
+	}
+	err := toml.NewDecoder(strings.NewReader(doc)).
+		RecordMetadata(&md).
+		CollectErrors().
+		Decode(&v)
+	require.Error(t, err)
+	assert.Equal(t, []toml.Key{
+		{"port"},
+		{"server", "host"},
+		{"server", "limits", "max"},
+	}, md.Undecoded())
+}
+
+func TestDecoderRecordMetadataReset(t *testing.T) {
+	var md toml.Metadata
+	var v map[string]interface{}
+	require.NoError(t, toml.NewDecoder(strings.NewReader("a = 1")).RecordMetadata(&md).Decode(&v))
+	require.NoError(t, toml.NewDecoder(strings.NewReader("b = 1")).RecordMetadata(&md).Decode(&v))
+	assert.False(t, md.IsDefined("a"))
+	assert.True(t, md.IsDefined("b"))
+	assert.Len(t, md.Keys(), 1)
+}
+
+func ExampleMetadata() {
+	doc := `
+port = 0
+`
+
+	var cfg struct {
+		Port    int
+		Timeout int
+	}
+
+	var md toml.Metadata
+	err := toml.NewDecoder(strings.NewReader(doc)).RecordMetadata(&md).Decode(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("port defined:", md.IsDefined("port"))
+	fmt.Println("timeout defined:", md.IsDefined("timeout"))
+
+	// Output:
+	// port defined: true
+	// timeout defined: false
+}
+
+func ExampleMetadata_ValueShape() {
+	doc := `
+[server]
+port = 99999
+`
+
+	var cfg struct {
+		Server struct {
+			Port int
+		}
+	}
+
+	var md toml.Metadata
+	err := toml.NewDecoder(strings.NewReader(doc)).RecordMetadata(&md).Decode(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	if cfg.Server.Port > 65535 {
+		shape, _ := md.ValueShape("server", "port")
+		fmt.Printf("%d:%d: port out of range\n", shape.Start.Line, shape.Start.Column)
+	}
+
+	// Output:
+	// 3:8: port out of range
+}
@@ -0,0 +1,180 @@
+package toml
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// OrderedMap is a table that remembers the order in which its keys have been
+// inserted. The zero value is an empty map ready to use.
+//
+// When decoding, the keys of an OrderedMap are inserted in the order they
+// appear in the document. See Decoder.UseOrderedMaps to decode all the tables
+// stored in interface{} values as *OrderedMap. When encoding, the keys of an
+// OrderedMap are written in order instead of being sorted.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{}
+}
+
+// Get returns the value of key, and whether it is present in m.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Set sets the value of key. A new key is added after the existing ones, while
+// an existing key keeps its position.
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if m.values == nil {
+		m.values = map[string]interface{}{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Delete removes key from m, if present.
+func (m *OrderedMap) Delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Keys returns the keys of m in order. The returned slice must not be
+// modified.
+func (m *OrderedMap) Keys() []string {
+	return m.keys
+}
+
+// Len returns the number of keys in m.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// UseOrderedMaps makes Decode store the tables decoded into interface{} values
+// as *OrderedMap instead of map[string]interface{}, so that the order of their
+// keys in the document is kept. This includes the top-level table when
+// decoding into an empty interface{}.
+func (d *Decoder) UseOrderedMaps() *Decoder {
+	d.orderedMaps = true
+	return d
+}
+
+// makeTable returns a new table to store in an interface{} value.
+func (d *decoder) makeTable() reflect.Value {
+	if d.orderedMaps {
+		return reflect.ValueOf(NewOrderedMap())
+	}
+	return makeMapStringInterface()
+}
+
+// tableType returns the type of the tables stored in interface{} values.
+func (d *decoder) tableType() reflect.Type {
+	if d.orderedMaps {
+		return orderedMapPtrType
+	}
+	return mapStringInterfaceType
+}
+
+// handleOrderedMapPart is the equivalent of handleKeyPart for an OrderedMap.
+func (d *decoder) handleOrderedMapPart(key unstable.Iterator, m *OrderedMap, nextFn handlerFn, makeFn valueMakerFn) (reflect.Value, error) {
+	k := string(key.Node().Data)
+
+	var mv reflect.Value
+	if x, ok := m.Get(k); ok && x != nil {
+		mv = reflect.ValueOf(x)
+	} else {
+		mv = makeFn()
+	}
+
+	x, err := nextFn(key, mv)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if x.IsValid() {
+		mv = x
+	}
+
+	m.Set(k, mv.Interface())
+
+	return reflect.Value{}, nil
+}
+
+// handleOrderedMapKeyValuePart is the equivalent of handleKeyValuePart for an
+// OrderedMap.
+func (d *decoder) handleOrderedMapKeyValuePart(key unstable.Iterator, value *unstable.Node, m *OrderedMap) (reflect.Value, error) {
+	k := string(key.Node().Data)
+
+	mv := reflect.New(emptyInterfaceType).Elem()
+	if x, ok := m.Get(k); ok && !key.IsLast() {
+		mv.Set(reflect.ValueOf(x))
+	}
+
+	x, err := d.handleKeyValueInner(key, value, mv)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if x.IsValid() {
+		mv = x
+	}
+
+	m.Set(k, mv.Interface())
+
+	return reflect.Value{}, nil
+}
+
+// encodeOrderedMap encodes m as a table, in the order of its keys.
+func (enc *Encoder) encodeOrderedMap(b []byte, ctx encoderCtx, m *OrderedMap) ([]byte, error) {
+	t, err := enc.orderedMapTable(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	ctx.variant = nil
+
+	return enc.encodeTable(b, ctx, t)
+}
+
+// orderedMapTable returns the entries of m, in the order of its keys.
+func (enc *Encoder) orderedMapTable(ctx encoderCtx, m *OrderedMap) (table, error) {
+	var t table
+
+	for _, k := range m.keys {
+		var options valueOptions
+		v, err := resolveMarshalers(unwrapCommented(reflect.ValueOf(m.values[k]), &options))
+		if err != nil {
+			ctx.shiftKey()
+			ctx.setKey(k)
+			return table{}, prependErrorPath(ctx.setErrorKey(err), "["+strconv.Quote(k)+"]")
+		}
+
+		if !v.IsValid() || isNil(v) {
+			continue
+		}
+
+		if willConvertToTableOrArrayTable(ctx, v) {
+			t.pushTable(k, v, options, "")
+		} else {
+			t.pushKV(k, v, options, "")
+		}
+	}
+
+	t.pushVariant(ctx.variant)
+
+	return t, nil
+}
@@ -0,0 +1,235 @@
+package toml_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var m toml.OrderedMap
+	assert.Equal(t, 0, m.Len())
+
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("c", 3)
+	m.Set("b", 4)
+	assert.Equal(t, []string{"b", "a", "c"}, m.Keys())
+
+	v, ok := m.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 4, v)
+
+	m.Delete("a")
+	m.Delete("missing")
+	assert.Equal(t, []string{"b", "c"}, m.Keys())
+	_, ok = m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.Len())
+}
+
+// orderedKeys returns the keys of the tables contained in v, in order.
+func orderedKeys(v interface{}) []string {
+	var keys []string
+	switch x := v.(type) {
+	case *toml.OrderedMap:
+		for _, k := range x.Keys() {
+			keys = append(keys, k)
+			e, _ := x.Get(k)
+			for _, sub := range orderedKeys(e) {
+				keys = append(keys, k+"."+sub)
+			}
+		}
+	case []interface{}:
+		for i, e := range x {
+			for _, sub := range orderedKeys(e) {
+				keys = append(keys, fmt.Sprintf("%d.%s", i, sub))
+			}
+		}
+	}
+	return keys
+}
+
+func TestDecoderUseOrderedMaps(t *testing.T) {
+	doc := `
+zeta = 1
+alpha.beta = 2
+alpha.aleph = 3
+inline = { z = 1, a = [{ y = 1, b = 2 }] }
+
+[table]
+y = 1
+x = 2
+
+[table.sub]
+b = 1
+
+[[items]]
+z = 1
+[items.child]
+b = 1
+a = 2
+
+[[items]]
+y = 2
+
+[alpha.gamma]
+c = 1
+`
+
+	expected := []string{
+		"zeta",
+		"alpha", "alpha.beta", "alpha.aleph", "alpha.gamma", "alpha.gamma.c",
+		"inline", "inline.z", "inline.a", "inline.a.0.y", "inline.a.0.b",
+		"table", "table.y", "table.x", "table.sub", "table.sub.b",
+		"items", "items.0.z", "items.0.child", "items.0.child.b", "items.0.child.a", "items.1.y",
+	}
+
+	var v interface{}
+	err := toml.NewDecoder(strings.NewReader(doc)).UseOrderedMaps().Decode(&v)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orderedKeys(v))
+
+	var streamed interface{}
+	err = toml.NewDecoder(strings.NewReader(doc)).UseOrderedMaps().EnableStreaming().Decode(&streamed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orderedKeys(streamed))
+
+	var m toml.OrderedMap
+	err = toml.NewDecoder(strings.NewReader(doc)).UseOrderedMaps().Decode(&m)
+	require.NoError(t, err)
+	assert.Equal(t, expected, orderedKeys(&m))
+
+	zeta, _ := m.Get("zeta")
+	assert.Equal(t, int64(1), zeta)
+}
+
+func TestUnmarshalOrderedMapField(t *testing.T) {
+	doc := `
+[env]
+PATH = "/bin"
+HOME = "/root"
+[env.extra]
+x = 1
+`
+
+	var c struct {
+		Env toml.OrderedMap
+	}
+	err := toml.Unmarshal([]byte(doc), &c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PATH", "HOME", "extra"}, c.Env.Keys())
+
+	// Without UseOrderedMaps, nested tables are regular maps.
+	extra, _ := c.Env.Get("extra")
+	assert.Equal(t, map[string]interface{}{"x": int64(1)}, extra)
+}
+
+func TestMarshalOrderedMap(t *testing.T) {
+	sub := toml.NewOrderedMap()
+	sub.Set("z", 1)
+	sub.Set("a", 2)
+
+	item := toml.NewOrderedMap()
+	item.Set("name", "first")
+
+	m := toml.NewOrderedMap()
+	m.Set("zeta", "z")
+	m.Set("sub", sub)
+	m.Set("alpha", 1.5)
+	m.Set("items", []interface{}{item})
+	m.Set("omitted", nil)
+	m.Set("inline", []interface{}{sub, 1})
+
+	b, err := toml.Marshal(m)
+	require.NoError(t, err)
+
+	expected := `zeta = 'z'
+alpha = 1.5
+inline = [{z = 1, a = 2}, 1]
+
+[sub]
+z = 1
+a = 2
+
+[[items]]
+name = 'first'
+`
+	assert.Equal(t, expected, string(b))
+
+	type config struct {
+		Env   toml.OrderedMap `toml:",omitempty"`
+		Other toml.OrderedMap `toml:",omitempty"`
+	}
+	var c config
+	c.Env.Set("b", 1)
+	c.Env.Set("a", 2)
+	b, err = toml.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, "[Env]\nb = 1\na = 2\n", string(b))
+}
+
+func TestOrderedMapRoundTrip(t *testing.T) {
+	doc := `title = 'example'
+numbers = [3, 1, 2]
+
+[servers]
+[servers.beta]
+ip = '10.0.0.2'
+role = 'backend'
+
+[servers.alpha]
+ip = '10.0.0.1'
+role = 'frontend'
+
+[[products]]
+name = 'Hammer'
+sku = 738594937
+
+[[products]]
+name = 'Nail'
+sku = 284758393
+`
+
+	var v interface{}
+	err := toml.NewDecoder(strings.NewReader(doc)).UseOrderedMaps().Decode(&v)
+	require.NoError(t, err)
+
+	b, err := toml.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, doc, string(b))
+}
+
+func ExampleDecoder_UseOrderedMaps() {
+	doc := `
+name = "app"
+version = 2
+
+[dependencies]
+zlib = "1.3"
+openssl = "3.0"
+`
+
+	var v interface{}
+	err := toml.NewDecoder(strings.NewReader(doc)).UseOrderedMaps().Decode(&v)
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := toml.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(b))
+	// Output:
+	// name = 'app'
+	// version = 2
+	//
+	// [dependencies]
+	// zlib = '1.3'
+	// openssl = '3.0'
+}
@@ -0,0 +1,74 @@
+package toml
+
+import (
+	"bytes"
+)
+
+// Position of a byte in a TOML document.
+type Position struct {
+	// Line number, starting at 1.
+	Line int
+	// Column number in bytes, starting at 1.
+	Column int
+	// Number of bytes from the beginning of the document.
+	Offset int
+}
+
+// Shape describes the location of a range of bytes in a TOML document.
+type Shape struct {
+	// Position of the first byte of the range.
+	Start Position
+	// Position right after the last byte of the range.
+	End Position
+}
+
+// positionTracker computes the Position of offsets in a chunk of the document.
+// Offsets are mostly requested close to each other, so the line number is
+// computed incrementally from the previous offset.
+type positionTracker struct {
+	data []byte
+
+	// Position of the first byte of data in the document.
+	baseOffset int
+
+	scanned   int
+	line      int
+	lineStart int
+}
+
+// Reset makes the tracker work on data, whose first byte is at the given
+// offset and line in the document.
+func (t *positionTracker) Reset(data []byte, offset, line int) {
+	t.data = data
+	t.baseOffset = offset
+	t.scanned = 0
+	t.line = line
+	t.lineStart = 0
+}
+
+// Position returns the position in the document of the byte at the given
+// offset of data.
+func (t *positionTracker) Position(offset int) Position {
+	if offset < t.scanned {
+		for t.scanned > offset {
+			t.scanned--
+			if t.data[t.scanned] == '\n' {
+				t.line--
+			}
+		}
+		t.lineStart = bytes.LastIndexByte(t.data[:t.scanned], '\n') + 1
+	}
+
+	for ; t.scanned < offset; t.scanned++ {
+		if t.data[t.scanned] == '\n' {
+			t.line++
+			t.lineStart = t.scanned + 1
+		}
+	}
+
+	return Position{
+		Line:   t.line,
+		Column: offset - t.lineStart + 1,
+		Offset: t.baseOffset + offset,
+	}
+}
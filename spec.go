@@ -0,0 +1,16 @@
+package toml
+
+import (
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// SpecVersion identifies a version of the TOML specification. See
+// Decoder.SetSpecVersion and Encoder.SetSpecVersion.
+type SpecVersion = unstable.SpecVersion
+
+const (
+	// TOML10 is TOML v1.0.0. It is the default.
+	TOML10 = unstable.TOML10
+	// TOML11 is TOML v1.1.0.
+	TOML11 = unstable.TOML11
+)
@@ -0,0 +1,214 @@
+package toml
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Number of lines preceding the current expression kept around to provide
+// context in errors.
+const streamContextLines = 3
+
+// exprReader splits a TOML document read from an io.Reader into chunks that
+// each contain one complete top-level expression, so that the parser can be
+// fed one expression at a time.
+//
+// A chunk is made of complete lines. The end of an expression is detected by
+// tracking whether the current line ends inside a multi-line string or an
+// array or inline table. This is just enough lexing to find boundaries:
+// malformed documents are passed through as-is for the parser to report the
+// error.
+//
+// The reader keeps a window of the last few lines before the current chunk,
+// so that errors can display some context.
+type exprReader struct {
+	r *bufio.Reader
+
+	// window contains the context lines followed by the current chunk.
+	window []byte
+	// Offset of the current chunk in window.
+	start int
+
+	// Line number of the first line in the window, starting at 1.
+	line int
+	// Offset of the first byte of the window in the document.
+	offset int
+
+	// Lexer state carried from one line to the next.
+	depth          int
+	inMultiBasic   bool
+	inMultiLiteral bool
+
+	err error
+}
+
+func newExprReader(r io.Reader) *exprReader {
+	return &exprReader{
+		r:    bufio.NewReader(r),
+		line: 1,
+	}
+}
+
+// Window returns the context lines and the current chunk.
+func (s *exprReader) Window() []byte {
+	return s.window
+}
+
+// Line returns the line number of the first byte of the window.
+func (s *exprReader) Line() int {
+	return s.line
+}
+
+// Offset returns the offset in the document of the first byte of the window.
+func (s *exprReader) Offset() int {
+	return s.offset
+}
+
+// Chunk returns the bytes of the current expression.
+func (s *exprReader) Chunk() []byte {
+	return s.window[s.start:]
+}
+
+// ChunkPosition returns the offset in the document and the line number of the
+// first byte of the current chunk.
+func (s *exprReader) ChunkPosition() (offset int, line int) {
+	return s.offset + s.start, s.line + bytes.Count(s.window[:s.start], []byte{'\n'})
+}
+
+// Err returns the error that occurred while reading, if any.
+func (s *exprReader) Err() error {
+	return s.err
+}
+
+// Next discards the current chunk and reads the next one. It returns false
+// when the end of the input is reached or an error occurred.
+//
+// Slices returned by previous calls to Window and Chunk are invalidated.
+func (s *exprReader) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	s.slide()
+
+	for {
+		n := len(s.window)
+		err := s.readLine()
+		line := s.window[n:]
+
+		if len(line) > 0 && s.scanLine(line) {
+			return true
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				s.err = err
+				return false
+			}
+			// Leave any incomplete expression to the parser, which is in
+			// charge of reporting the error.
+			return len(s.window) > s.start
+		}
+	}
+}
+
+// slide drops the bytes that are not needed anymore from the window: only
+// the last streamContextLines lines are kept.
+func (s *exprReader) slide() {
+	keep := len(s.window)
+	for i := 0; i < streamContextLines && keep > 0; i++ {
+		idx := bytes.LastIndexByte(s.window[:keep-1], '\n')
+		keep = idx + 1
+	}
+
+	s.line += bytes.Count(s.window[:keep], []byte{'\n'})
+	s.offset += keep
+	n := copy(s.window, s.window[keep:])
+	s.window = s.window[:n]
+	s.start = n
+}
+
+// readLine appends the next line of input to the window, including its new
+// line character.
+func (s *exprReader) readLine() error {
+	for {
+		b, err := s.r.ReadSlice('\n')
+		s.window = append(s.window, b...)
+		if err != bufio.ErrBufferFull {
+			return err
+		}
+	}
+}
+
+// scanLine updates the lexer state with the content of line and returns true
+// if an expression is complete at the end of the line.
+//
+//nolint:cyclop
+func (s *exprReader) scanLine(line []byte) bool {
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case s.inMultiBasic:
+			if c == '\\' {
+				i++
+			} else if scanFollows(line[i:], `"""`) {
+				s.inMultiBasic = false
+				i += 2
+			}
+		case s.inMultiLiteral:
+			if scanFollows(line[i:], `'''`) {
+				s.inMultiLiteral = false
+				i += 2
+			}
+		case c == '#':
+			i = len(line)
+		case c == '"':
+			if scanFollows(line[i:], `"""`) {
+				s.inMultiBasic = true
+				i += 2
+			} else {
+				i = skipQuoted(line, i, '"')
+			}
+		case c == '\'':
+			if scanFollows(line[i:], `'''`) {
+				s.inMultiLiteral = true
+				i += 2
+			} else {
+				i = skipQuoted(line, i, '\'')
+			}
+		case c == '[' || c == '{':
+			s.depth++
+		case c == ']' || c == '}':
+			s.depth--
+		}
+	}
+
+	if s.inMultiBasic || s.inMultiLiteral || s.depth > 0 {
+		return false
+	}
+
+	s.depth = 0
+	return true
+}
+
+// skipQuoted returns the index of the closing quote of the single-line string
+// starting at index i of line.
+func skipQuoted(line []byte, i int, quote byte) int {
+	for i++; i < len(line); i++ {
+		switch line[i] {
+		case quote:
+			return i
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+func scanFollows(b []byte, pattern string) bool {
+	return len(b) >= len(pattern) && string(b[:len(pattern)]) == pattern
+}
@@ -0,0 +1,193 @@
+package toml_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderStreaming(t *testing.T) {
+	examples := []struct {
+		desc  string
+		input string
+	}{
+		{
+			desc:  "empty",
+			input: ``,
+		},
+		{
+			desc:  "key values",
+			input: "a = 1\nb = 'two'\r\nc.d = true",
+		},
+		{
+			desc: "tables and comments",
+			input: `# comment
+[a] # comment [
+b = 1
+
+[[c]]
+d = "]"
+[[c]]
+d = '{'
+[c.e]
+f = 2021-01-01T10:00:00Z`,
+		},
+		{
+			desc: "multiline values",
+			input: `a = [
+  1, # one ]
+  2,
+  [3, [
+    4]],
+]
+b = """
+x = 1
+[not a table]
+\"""
+"""
+c = '''
+]]]
+'''
+d = { e = [1,
+2], f = "}" }
+g = 'h'`,
+		},
+		{
+			desc:  "array tables with sub tables",
+			input: "[[a]]\nb = 1\n[a.c]\nd = 2\n[[a]]\nb = 3\n[[a.e]]\nf = 4\n",
+		},
+	}
+
+	for _, e := range examples {
+		t.Run(e.desc, func(t *testing.T) {
+			var expected interface{}
+			require.NoError(t, toml.Unmarshal([]byte(e.input), &expected))
+
+			var actual interface{}
+			r := iotest.OneByteReader(strings.NewReader(e.input))
+			err := toml.NewDecoder(r).EnableStreaming().Decode(&actual)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestDecoderStreamingErrors(t *testing.T) {
+	examples := []struct {
+		desc   string
+		input  string
+		target interface{}
+		line   int
+		column int
+		human  string
+	}{
+		{
+			desc:   "syntax error",
+			input:  "a = 1\nb = 2\nc = 3\nd = 4\ne = [1,\n2 3]\nf = 6\n",
+			target: &map[string]interface{}{},
+			line:   6,
+			column: 3,
+			human: `3| c = 3
+4| d = 4
+5| e = [1,
+6| 2 3]
+ |   ~ array elements must be separated by commas`,
+		},
+		{
+			desc:   "type mismatch",
+			input:  "[server]\nport = 1\nhost = 2\n",
+			target: &struct{ Server struct{ Host string } }{},
+			line:   3,
+			column: 8,
+			human: `1| [server]
+2| port = 1
+3| host = 2
+ |        ~ cannot decode TOML integer into struct field struct { Host string }.Host of type string`,
+		},
+		{
+			desc:   "unterminated multiline string",
+			input:  "a = 1\nb = '''\nhello\n",
+			target: &map[string]interface{}{},
+			line:   4,
+			column: 1,
+		},
+	}
+
+	for _, e := range examples {
+		t.Run(e.desc, func(t *testing.T) {
+			err := toml.NewDecoder(strings.NewReader(e.input)).EnableStreaming().Decode(e.target)
+			require.Error(t, err)
+
+			var derr *toml.DecodeError
+			require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+
+			line, column := derr.Position()
+			assert.Equal(t, e.line, line)
+			assert.Equal(t, e.column, column)
+			if e.human != "" {
+				assert.Equal(t, e.human, derr.String())
+			}
+		})
+	}
+}
+
+func TestDecoderStreamingStrict(t *testing.T) {
+	input := "a = 1\nb = 2\n\n[c]\nd = 3\n"
+
+	var doc struct {
+		A int
+	}
+	err := toml.NewDecoder(strings.NewReader(input)).EnableStreaming().DisallowUnknownFields().Decode(&doc)
+	require.Error(t, err)
+
+	var serr *toml.StrictMissingError
+	require.True(t, errors.As(err, &serr))
+	require.Len(t, serr.Errors, 2)
+
+	line, _ := serr.Errors[0].Position()
+	assert.Equal(t, 2, line)
+	assert.Equal(t, toml.Key{"b"}, serr.Errors[0].Key())
+
+	line, _ = serr.Errors[1].Position()
+	assert.Equal(t, 4, line)
+	assert.Equal(t, toml.Key{"c"}, serr.Errors[1].Key())
+}
+
+func TestDecoderStreamingLargeDocument(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; i < 10000; i++ {
+		buf.WriteString("[[items]]\nname = \"item\"\nvalue = 42\n\n")
+	}
+
+	var doc struct {
+		Items []struct {
+			Name  string
+			Value int
+		}
+	}
+	err := toml.NewDecoder(&buf).EnableStreaming().Decode(&doc)
+	require.NoError(t, err)
+	require.Len(t, doc.Items, 10000)
+	assert.Equal(t, "item", doc.Items[9999].Name)
+	assert.Equal(t, 42, doc.Items[9999].Value)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+func TestDecoderStreamingReadError(t *testing.T) {
+	var v interface{}
+	err := toml.NewDecoder(failingReader{}).EnableStreaming().Decode(&v)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
@@ -9,83 +9,211 @@ import (
 type strict struct {
 	Enabled bool
 
+	// Records the keys of the document when not nil.
+	Metadata *Metadata
+
+	// Name of the document, reported in errors.
+	Source string
+
 	// Tracks the current key being processed.
 	key tracker.KeyTracker
 
-	missing []unstable.ParserError
+	// Positions of the keys recorded in Metadata.
+	positions positionTracker
+
+	// Index in Metadata of the current table, if any, and of the key-values
+	// being processed.
+	table    int
+	hasTable bool
+	pending  []int
+
+	// Missing fields that still reference the document.
+	missing []missingField
+
+	// Missing fields that have been contextualized.
+	errors []DecodeError
+}
+
+// tracking returns true if the current key needs to be tracked.
+func (s *strict) tracking() bool {
+	return s.Enabled || s.Metadata != nil
+}
+
+// SetChunk needs to be called when the parser is reset with a new chunk of the
+// document, whose first byte is located at the given offset and line.
+func (s *strict) SetChunk(data []byte, offset, line int) {
+	if s.Metadata == nil {
+		return
+	}
+
+	s.positions.Reset(data, offset, line)
 }
 
 func (s *strict) EnterTable(node *unstable.Node) {
-	if !s.Enabled {
+	if !s.tracking() {
 		return
 	}
 
 	s.key.UpdateTable(node)
+	s.enter(s.record(node))
 }
 
 func (s *strict) EnterArrayTable(node *unstable.Node) {
-	if !s.Enabled {
+	if !s.tracking() {
 		return
 	}
 
 	s.key.UpdateArrayTable(node)
+	s.enter(s.record(node))
+}
+
+// enter makes the table recorded at index idx in the metadata the current one.
+func (s *strict) enter(idx int) {
+	if s.Metadata != nil {
+		s.table = idx
+		s.hasTable = true
+	}
 }
 
 func (s *strict) EnterKeyValue(node *unstable.Node) {
-	if !s.Enabled {
+	if !s.tracking() {
 		return
 	}
 
 	s.key.Push(node)
+	if s.Metadata != nil {
+		s.pending = append(s.pending, s.record(node))
+	}
 }
 
 func (s *strict) ExitKeyValue(node *unstable.Node) {
-	if !s.Enabled {
+	if !s.tracking() {
 		return
 	}
 
 	s.key.Pop(node)
+	if s.Metadata != nil {
+		s.pending = s.pending[:len(s.pending)-1]
+	}
+}
+
+// SkippedKeyValue records a key-value that is part of a table missing from
+// the target value.
+func (s *strict) SkippedKeyValue(node *unstable.Node) {
+	if s.Metadata == nil {
+		return
+	}
+
+	s.key.Push(node)
+	s.Metadata.keys[s.record(node)].Decoded = false
+	s.key.Pop(node)
+}
+
+// record adds the current key to the metadata, if enabled.
+func (s *strict) record(node *unstable.Node) int {
+	if s.Metadata == nil {
+		return -1
+	}
+
+	offset := danger.SubsliceOffset(s.positions.data, keyLocation(node))
+	pos := s.positions.Position(offset)
+
+	var value Shape
+	if node.Kind == unstable.KeyValue {
+		r := node.Value().Extent()
+		value.Start = s.positions.Position(int(r.Offset))
+		value.End = s.positions.Position(int(r.Offset + r.Length))
+	}
+
+	return s.Metadata.add(s.key.Key(), pos, value)
 }
 
 func (s *strict) MissingTable(node *unstable.Node) {
+	if s.Metadata != nil && s.hasTable {
+		s.Metadata.keys[s.table].Decoded = false
+	}
+
 	if !s.Enabled {
 		return
 	}
 
-	s.missing = append(s.missing, unstable.ParserError{
-		Highlight: keyLocation(node),
-		Message:   "missing table",
-		Key:       s.key.Key(),
+	s.missing = append(s.missing, missingField{
+		ParserError: unstable.ParserError{
+			Highlight: keyLocation(node),
+			Message:   "missing table",
+			Key:       s.key.Key(),
+		},
+		found: tomlKind(node.Kind),
 	})
 }
 
+// Undecoded marks the key-value being processed, or the current table outside
+// of key-values, as not decoded, when an error is collected for its value.
+func (s *strict) Undecoded() {
+	switch {
+	case s.Metadata == nil:
+	case len(s.pending) > 0:
+		s.Metadata.keys[s.pending[len(s.pending)-1]].Decoded = false
+	case s.hasTable:
+		s.Metadata.keys[s.table].Decoded = false
+	}
+}
+
 func (s *strict) MissingField(node *unstable.Node) {
+	if s.Metadata != nil {
+		s.Metadata.keys[s.pending[len(s.pending)-1]].Decoded = false
+	}
+
 	if !s.Enabled {
 		return
 	}
 
-	s.missing = append(s.missing, unstable.ParserError{
-		Highlight: keyLocation(node),
-		Message:   "missing field",
-		Key:       s.key.Key(),
+	s.missing = append(s.missing, missingField{
+		ParserError: unstable.ParserError{
+			Highlight: keyLocation(node),
+			Message:   "missing field",
+			Key:       s.key.Key(),
+		},
+		found: tomlKind(node.Value().Kind),
 	})
 }
 
-func (s *strict) Error(doc []byte) error {
-	if !s.Enabled || len(s.missing) == 0 {
-		return nil
+// Flush contextualizes the missing fields collected so far. It needs to be
+// called before the document they reference is discarded. The first byte of
+// doc is located at the given line and offset.
+func (s *strict) Flush(doc []byte, line, offset int) {
+	if !s.Enabled {
+		return
 	}
 
-	err := &StrictMissingError{
-		Errors: make([]DecodeError, 0, len(s.missing)),
+	for _, m := range s.missing {
+		perr := m.ParserError
+		e := wrapDecodeErrorAt(doc, line, offset, &perr)
+		e.source = s.Source
+		e.code = CodeUnknownField
+		e.found = m.found
+		s.errors = append(s.errors, *e)
 	}
+	s.missing = s.missing[:0]
+}
+
+// missingField is a key of the document missing in the target, with the kind
+// of its value.
+type missingField struct {
+	unstable.ParserError
+	found string
+}
 
-	for _, derr := range s.missing {
-		derr := derr
-		err.Errors = append(err.Errors, *wrapDecodeError(doc, &derr))
+func (s *strict) Error(doc []byte, line, offset int) error {
+	s.Flush(doc, line, offset)
+
+	if !s.Enabled || len(s.errors) == 0 {
+		return nil
 	}
 
-	return err
+	return &StrictMissingError{
+		Errors: s.errors,
+	}
 }
 
 func keyLocation(node *unstable.Node) []byte {
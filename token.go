@@ -0,0 +1,361 @@
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// TokenKind describes the kind of event returned by Decoder.Token.
+type TokenKind int
+
+const (
+	// InvalidToken is the zero value of TokenKind. It is never returned by
+	// Decoder.Token.
+	InvalidToken TokenKind = iota
+	// TableToken is a [table] header. Its key is in Token.KeyParts.
+	TableToken
+	// ArrayTableToken is an [[array table]] header. Its key is in
+	// Token.KeyParts.
+	ArrayTableToken
+	// KeyToken is the key of a key-value, either at the top level of a table
+	// or in an inline table. Its parts are in Token.KeyParts. It is always
+	// followed by the tokens of its value.
+	KeyToken
+	// ValueToken is a scalar value. Its type is Token.ValueKind and its
+	// content is in Token.Data.
+	ValueToken
+	// ArrayStartToken is the opening bracket of an array.
+	ArrayStartToken
+	// ArrayEndToken is the closing bracket of an array.
+	ArrayEndToken
+	// InlineTableStartToken is the opening brace of an inline table.
+	InlineTableStartToken
+	// InlineTableEndToken is the closing brace of an inline table.
+	InlineTableEndToken
+	// CommentToken is a comment. Its text, including the leading '#', is in
+	// Token.Data.
+	CommentToken
+)
+
+// String implementation of fmt.Stringer.
+func (k TokenKind) String() string {
+	switch k {
+	case InvalidToken:
+		return "Invalid"
+	case TableToken:
+		return "Table"
+	case ArrayTableToken:
+		return "ArrayTable"
+	case KeyToken:
+		return "Key"
+	case ValueToken:
+		return "Value"
+	case ArrayStartToken:
+		return "ArrayStart"
+	case ArrayEndToken:
+		return "ArrayEnd"
+	case InlineTableStartToken:
+		return "InlineTableStart"
+	case InlineTableEndToken:
+		return "InlineTableEnd"
+	case CommentToken:
+		return "Comment"
+	}
+	panic(fmt.Errorf("TokenKind.String() not implemented for '%d'", k))
+}
+
+// ValueKind is the TOML type of a scalar value.
+type ValueKind int
+
+const (
+	// InvalidValue is the zero value of ValueKind.
+	InvalidValue ValueKind = iota
+	StringValue
+	IntegerValue
+	FloatValue
+	BoolValue
+	OffsetDateTimeValue
+	LocalDateTimeValue
+	LocalDateValue
+	LocalTimeValue
+)
+
+// String implementation of fmt.Stringer.
+func (k ValueKind) String() string {
+	switch k {
+	case InvalidValue:
+		return "Invalid"
+	case StringValue:
+		return "String"
+	case IntegerValue:
+		return "Integer"
+	case FloatValue:
+		return "Float"
+	case BoolValue:
+		return "Bool"
+	case OffsetDateTimeValue:
+		return "OffsetDateTime"
+	case LocalDateTimeValue:
+		return "LocalDateTime"
+	case LocalDateValue:
+		return "LocalDate"
+	case LocalTimeValue:
+		return "LocalTime"
+	}
+	panic(fmt.Errorf("ValueKind.String() not implemented for '%d'", k))
+}
+
+func valueKindOf(k unstable.Kind) ValueKind {
+	switch k {
+	case unstable.String:
+		return StringValue
+	case unstable.Integer:
+		return IntegerValue
+	case unstable.Float:
+		return FloatValue
+	case unstable.Bool:
+		return BoolValue
+	case unstable.DateTime:
+		return OffsetDateTimeValue
+	case unstable.LocalDateTime:
+		return LocalDateTimeValue
+	case unstable.LocalDate:
+		return LocalDateValue
+	case unstable.LocalTime:
+		return LocalTimeValue
+	}
+	return InvalidValue
+}
+
+// Token is an event of the document returned by Decoder.Token.
+//
+// Slices contained in a Token reference memory owned by the Decoder. They are
+// only valid until the next call to Token.
+type Token struct {
+	Kind TokenKind
+
+	// Type of the value of a ValueToken.
+	ValueKind ValueKind
+
+	// Parts of the key of a TableToken, ArrayTableToken, or KeyToken. A
+	// simple key has only one part.
+	KeyParts [][]byte
+
+	// Content of a ValueToken or CommentToken. Strings are unescaped, other
+	// values are as written in the document.
+	Data []byte
+
+	// Position of the first byte of the token in the document.
+	Position Position
+}
+
+// Token returns the next event of the document. At the end of the document, it
+// returns io.EOF.
+//
+// Token only checks the syntax of the document: it does not verify that keys
+// are not redefined, and no Go value is created. A syntax error is returned as
+// a DecodeError. It is not possible to call Decode once Token has been called.
+//
+// Key-values generate a KeyToken followed by the tokens of the value. Arrays
+// and inline tables generate a start token, the tokens of their content, and
+// an end token. For example, the document:
+//
+//	[server]
+//	ports = [80, 443] # web
+//
+// results in the following tokens:
+//
+//	TableToken(server)
+//	KeyToken(ports)
+//	ArrayStartToken
+//	ValueToken(Integer, 80)
+//	ValueToken(Integer, 443)
+//	ArrayEndToken
+//	CommentToken(# web)
+//
+// When streaming is enabled, only the current expression is kept in memory.
+func (d *Decoder) Token() (Token, error) {
+	if d.tokens == nil {
+		d.tokens = &tokenReader{}
+		d.tokens.p.KeepComments = true
+		d.tokens.p.SpecVersion = d.spec
+		d.tokens.p.Origin = d.name
+		if d.streaming {
+			d.tokens.stream = newExprReader(d.r)
+		} else {
+			b, err := ioutil.ReadAll(d.r)
+			if err != nil {
+				d.tokens.err = fmt.Errorf("toml: %w", err)
+			}
+			d.tokens.p.Reset(b)
+			d.tokens.positions.Reset(b, 0, 1)
+		}
+	}
+
+	return d.tokens.Next()
+}
+
+// tokenReader flattens the expressions returned by the parser into a
+// sequence of tokens.
+type tokenReader struct {
+	p      unstable.Parser
+	stream *exprReader
+
+	// Tokens of the current expression.
+	queue []Token
+	idx   int
+	// Storage for the KeyParts of the tokens in the queue.
+	keys [][]byte
+
+	positions positionTracker
+
+	err error
+}
+
+func (t *tokenReader) Next() (Token, error) {
+	for t.idx >= len(t.queue) {
+		if t.err != nil {
+			return Token{}, t.err
+		}
+
+		if t.p.NextExpression() {
+			t.queue = t.queue[:0]
+			t.keys = t.keys[:0]
+			t.idx = 0
+			t.appendExpression(t.p.Expression())
+			continue
+		}
+
+		t.err = t.nextChunk()
+	}
+
+	tok := t.queue[t.idx]
+	t.idx++
+
+	return tok, nil
+}
+
+// nextChunk is called when the parser ran out of expressions. It loads the
+// next chunk of input when streaming, or returns the error that ends the
+// sequence of tokens.
+func (t *tokenReader) nextChunk() error {
+	var perr *unstable.ParserError
+	if errors.As(t.p.Error(), &perr) {
+		var derr *DecodeError
+		if t.stream != nil {
+			derr = wrapDecodeErrorAt(t.stream.Window(), t.stream.Line(), t.stream.Offset(), perr)
+		} else {
+			derr = wrapDecodeError(t.p.Data(), perr)
+		}
+		derr.source = t.p.Origin
+		return derr
+	}
+
+	if t.stream == nil {
+		return io.EOF
+	}
+
+	if !t.stream.Next() {
+		if err := t.stream.Err(); err != nil {
+			return fmt.Errorf("toml: %w", err)
+		}
+		return io.EOF
+	}
+
+	t.p.Reset(t.stream.Chunk())
+	offset, line := t.stream.ChunkPosition()
+	t.positions.Reset(t.stream.Chunk(), offset, line)
+
+	return nil
+}
+
+func (t *tokenReader) push(kind TokenKind, offset int) *Token {
+	t.queue = append(t.queue, Token{
+		Kind:     kind,
+		Position: t.positions.Position(offset),
+	})
+	return &t.queue[len(t.queue)-1]
+}
+
+func (t *tokenReader) pushKey(kind TokenKind, offset int, node *unstable.Node) {
+	start := len(t.keys)
+	it := node.Key()
+	for it.Next() {
+		t.keys = append(t.keys, it.Node().Data)
+	}
+
+	tok := t.push(kind, offset)
+	tok.KeyParts = t.keys[start:len(t.keys):len(t.keys)]
+}
+
+func (t *tokenReader) appendExpression(node *unstable.Node) {
+	switch node.Kind {
+	case unstable.Comment:
+		t.appendComment(node)
+		return
+	case unstable.Table:
+		t.pushKey(TableToken, int(node.Extent().Offset), node)
+	case unstable.ArrayTable:
+		t.pushKey(ArrayTableToken, int(node.Extent().Offset), node)
+	case unstable.KeyValue:
+		t.appendKeyValue(node)
+	default:
+		panic(fmt.Errorf("parser should not permit expression of kind %s at document root", node.Kind))
+	}
+
+	if c := node.Next(); c != nil {
+		t.appendComment(c)
+	}
+}
+
+func (t *tokenReader) appendKeyValue(node *unstable.Node) {
+	it := node.Key()
+	it.Next()
+	t.pushKey(KeyToken, int(it.Node().Raw.Offset), node)
+	t.appendValue(node.Value())
+}
+
+// appendComment adds the tokens for a comment node. In arrays, consecutive
+// comments are stored as children of the first one.
+func (t *tokenReader) appendComment(node *unstable.Node) {
+	tok := t.push(CommentToken, int(node.Raw.Offset))
+	tok.Data = node.Data
+
+	it := node.Children()
+	for it.Next() {
+		t.appendComment(it.Node())
+	}
+}
+
+func (t *tokenReader) appendValue(node *unstable.Node) {
+	raw := node.Extent()
+	start := int(raw.Offset)
+	end := start + int(raw.Length) - 1
+
+	switch node.Kind {
+	case unstable.Comment:
+		t.appendComment(node)
+	case unstable.Array:
+		t.push(ArrayStartToken, start)
+		it := node.Children()
+		for it.Next() {
+			t.appendValue(it.Node())
+		}
+		t.push(ArrayEndToken, end)
+	case unstable.InlineTable:
+		t.push(InlineTableStartToken, start)
+		it := node.Children()
+		for it.Next() {
+			t.appendKeyValue(it.Node())
+		}
+		t.push(InlineTableEndToken, end)
+	default:
+		tok := t.push(ValueToken, start)
+		tok.ValueKind = valueKindOf(node.Kind)
+		tok.Data = node.Data
+	}
+}
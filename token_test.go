@@ -0,0 +1,212 @@
+package toml_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tokenSummary struct {
+	Kind      toml.TokenKind
+	ValueKind toml.ValueKind
+	Key       string
+	Data      string
+	Line      int
+	Column    int
+	Offset    int
+}
+
+func collectTokens(t *testing.T, d *toml.Decoder) []tokenSummary {
+	t.Helper()
+
+	var tokens []tokenSummary
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return tokens
+		}
+		require.NoError(t, err)
+
+		parts := make([]string, len(tok.KeyParts))
+		for i, p := range tok.KeyParts {
+			parts[i] = string(p)
+		}
+
+		tokens = append(tokens, tokenSummary{
+			Kind:      tok.Kind,
+			ValueKind: tok.ValueKind,
+			Key:       strings.Join(parts, "."),
+			Data:      string(tok.Data),
+			Line:      tok.Position.Line,
+			Column:    tok.Position.Column,
+			Offset:    tok.Position.Offset,
+		})
+	}
+}
+
+func TestDecoderToken(t *testing.T) {
+	doc := `# top
+a = "x\ty"
+[b.c] # header
+d = [1, 2.5, # one
+  true]
+e = {f = 1979-05-27, g = {}}
+[["h"]]
+i = 07:32:00
+`
+
+	expected := []tokenSummary{
+		{Kind: toml.CommentToken, Data: "# top", Line: 1, Column: 1, Offset: 0},
+		{Kind: toml.KeyToken, Key: "a", Line: 2, Column: 1, Offset: 6},
+		{Kind: toml.ValueToken, ValueKind: toml.StringValue, Data: "x\ty", Line: 2, Column: 5, Offset: 10},
+		{Kind: toml.TableToken, Key: "b.c", Line: 3, Column: 1, Offset: 17},
+		{Kind: toml.CommentToken, Data: "# header", Line: 3, Column: 7, Offset: 23},
+		{Kind: toml.KeyToken, Key: "d", Line: 4, Column: 1, Offset: 32},
+		{Kind: toml.ArrayStartToken, Line: 4, Column: 5, Offset: 36},
+		{Kind: toml.ValueToken, ValueKind: toml.IntegerValue, Data: "1", Line: 4, Column: 6, Offset: 37},
+		{Kind: toml.ValueToken, ValueKind: toml.FloatValue, Data: "2.5", Line: 4, Column: 9, Offset: 40},
+		{Kind: toml.CommentToken, Data: "# one", Line: 4, Column: 14, Offset: 45},
+		{Kind: toml.ValueToken, ValueKind: toml.BoolValue, Data: "true", Line: 5, Column: 3, Offset: 53},
+		{Kind: toml.ArrayEndToken, Line: 5, Column: 7, Offset: 57},
+		{Kind: toml.KeyToken, Key: "e", Line: 6, Column: 1, Offset: 59},
+		{Kind: toml.InlineTableStartToken, Line: 6, Column: 5, Offset: 63},
+		{Kind: toml.KeyToken, Key: "f", Line: 6, Column: 6, Offset: 64},
+		{Kind: toml.ValueToken, ValueKind: toml.LocalDateValue, Data: "1979-05-27", Line: 6, Column: 10, Offset: 68},
+		{Kind: toml.KeyToken, Key: "g", Line: 6, Column: 22, Offset: 80},
+		{Kind: toml.InlineTableStartToken, Line: 6, Column: 26, Offset: 84},
+		{Kind: toml.InlineTableEndToken, Line: 6, Column: 27, Offset: 85},
+		{Kind: toml.InlineTableEndToken, Line: 6, Column: 28, Offset: 86},
+		{Kind: toml.ArrayTableToken, Key: "h", Line: 7, Column: 1, Offset: 88},
+		{Kind: toml.KeyToken, Key: "i", Line: 8, Column: 1, Offset: 96},
+		{Kind: toml.ValueToken, ValueKind: toml.LocalTimeValue, Data: "07:32:00", Line: 8, Column: 5, Offset: 100},
+	}
+
+	t.Run("buffered", func(t *testing.T) {
+		d := toml.NewDecoder(strings.NewReader(doc))
+		assert.Equal(t, expected, collectTokens(t, d))
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		d := toml.NewDecoder(iotest.OneByteReader(strings.NewReader(doc))).EnableStreaming()
+		assert.Equal(t, expected, collectTokens(t, d))
+	})
+}
+
+func TestDecoderTokenEmpty(t *testing.T) {
+	d := toml.NewDecoder(strings.NewReader(""))
+	_, err := d.Token()
+	assert.Equal(t, io.EOF, err)
+	_, err = d.Token()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestDecoderTokenErrors(t *testing.T) {
+	doc := "a = 1\nb = [1,\n2 3]\n"
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			d := toml.NewDecoder(strings.NewReader(doc))
+			if streaming {
+				d.EnableStreaming()
+			}
+
+			tok, err := d.Token()
+			require.NoError(t, err)
+			assert.Equal(t, toml.KeyToken, tok.Kind)
+			tok, err = d.Token()
+			require.NoError(t, err)
+			assert.Equal(t, toml.ValueToken, tok.Kind)
+
+			_, err = d.Token()
+			var derr *toml.DecodeError
+			require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+			line, column := derr.Position()
+			assert.Equal(t, 3, line)
+			assert.Equal(t, 3, column)
+
+			_, err2 := d.Token()
+			assert.Equal(t, err, err2)
+		})
+	}
+}
+
+func TestDecoderTokenReadError(t *testing.T) {
+	_, err := toml.NewDecoder(failingReader{}).Token()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	_, err = toml.NewDecoder(failingReader{}).EnableStreaming().Token()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestDecoderTokenThenDecode(t *testing.T) {
+	d := toml.NewDecoder(strings.NewReader("a = 1\n"))
+	_, err := d.Token()
+	require.NoError(t, err)
+
+	var v map[string]interface{}
+	err = d.Decode(&v)
+	require.Error(t, err)
+	assert.Equal(t, "toml: Decode cannot be called after Token", err.Error())
+}
+
+func TestDecoderTokenPositions(t *testing.T) {
+	doc := "[a]\nb = [1, { c = 2 }]\n[[d]]\n"
+
+	d := toml.NewDecoder(strings.NewReader(doc))
+	var offsets []int
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		offsets = append(offsets, tok.Position.Offset)
+	}
+
+	assert.Equal(t, []int{0, 4, 8, 9, 12, 14, 18, 20, 21, 23}, offsets)
+}
+
+func ExampleDecoder_Token() {
+	doc := `
+[server]
+ports = [80, 443] # web
+`
+
+	d := toml.NewDecoder(strings.NewReader(doc))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+
+		switch tok.Kind {
+		case toml.TableToken, toml.KeyToken:
+			fmt.Printf("%d:%d %s %s\n", tok.Position.Line, tok.Position.Column, tok.Kind, tok.KeyParts[0])
+		case toml.ValueToken:
+			fmt.Printf("%d:%d %s %s %s\n", tok.Position.Line, tok.Position.Column, tok.Kind, tok.ValueKind, tok.Data)
+		default:
+			fmt.Printf("%d:%d %s\n", tok.Position.Line, tok.Position.Column, tok.Kind)
+		}
+	}
+
+	// Output:
+	// 2:1 Table server
+	// 3:1 Key ports
+	// 3:9 ArrayStart
+	// 3:10 Value Integer 80
+	// 3:14 Value Integer 443
+	// 3:17 ArrayEnd
+	// 3:19 Comment
+}
@@ -1,5 +1,6 @@
 //go:generate go run github.com/toml-lang/toml-test/cmd/toml-test@master -copy ./tests
 //go:generate go run ./cmd/tomltestgen/main.go -o toml_testgen_test.go
+//go:generate go run ./cmd/tomltestgen/main.go -toml 1.1.0 -o toml_testgen_v11_test.go
 
 // This is a support file for toml_testgen_test.go
 package toml_test
@@ -14,11 +15,21 @@ import (
 )
 
 func testgenInvalid(t *testing.T, input string) {
+	t.Helper()
+	testgenInvalidSpec(t, toml.TOML10, input)
+}
+
+func testgenInvalid11(t *testing.T, input string) {
+	t.Helper()
+	testgenInvalidSpec(t, toml.TOML11, input)
+}
+
+func testgenInvalidSpec(t *testing.T, spec toml.SpecVersion, input string) {
 	t.Helper()
 	t.Logf("Input TOML:\n%s", input)
 
 	doc := map[string]interface{}{}
-	err := testsuite.Unmarshal([]byte(input), &doc)
+	err := testsuite.UnmarshalSpec([]byte(input), &doc, spec)
 
 	if err == nil {
 		out, err := json.Marshal(doc)
@@ -31,13 +42,23 @@ func testgenInvalid(t *testing.T, input string) {
 }
 
 func testgenValid(t *testing.T, input string, jsonRef string) {
+	t.Helper()
+	testgenValidSpec(t, toml.TOML10, input, jsonRef)
+}
+
+func testgenValid11(t *testing.T, input string, jsonRef string) {
+	t.Helper()
+	testgenValidSpec(t, toml.TOML11, input, jsonRef)
+}
+
+func testgenValidSpec(t *testing.T, spec toml.SpecVersion, input string, jsonRef string) {
 	t.Helper()
 	t.Logf("Input TOML:\n%s", input)
 
 	// TODO: change this to interface{}
 	var doc map[string]interface{}
 
-	err := testsuite.Unmarshal([]byte(input), &doc)
+	err := testsuite.UnmarshalSpec([]byte(input), &doc, spec)
 	if err != nil {
 		if de, ok := err.(*toml.DecodeError); ok {
 			t.Logf("%s\n%s", err, de)
@@ -4,11 +4,23 @@ import (
 	"encoding"
 	"reflect"
 	"time"
+
+	"github.com/pelletier/go-toml/v2/unstable"
 )
 
 var timeType = reflect.TypeOf((*time.Time)(nil)).Elem()
 var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
 var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 var mapStringInterfaceType = reflect.TypeOf(map[string]interface{}(nil))
+var emptyInterfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
 var sliceInterfaceType = reflect.TypeOf([]interface{}(nil))
 var stringType = reflect.TypeOf("")
+var localDateType = reflect.TypeOf(LocalDate{})
+var localTimeType = reflect.TypeOf(LocalTime{})
+var localDateTimeType = reflect.TypeOf(LocalDateTime{})
+var orderedMapType = reflect.TypeOf(OrderedMap{})
+var orderedMapPtrType = reflect.TypeOf(&OrderedMap{})
+var commentedType = reflect.TypeOf(Commented{})
+var commentedPtrType = reflect.TypeOf(&Commented{})
+var unmarshalerType = reflect.TypeOf((*unstable.Unmarshaler)(nil)).Elem()
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"math"
+	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -38,6 +41,38 @@ type Decoder struct {
 
 	// toggles unmarshaler interface
 	unmarshalerInterface bool
+
+	// read and decode the document one expression at a time
+	streaming bool
+
+	// version of the specification the document is decoded against
+	spec SpecVersion
+
+	// filled with the keys of the document when not nil
+	metadata *Metadata
+
+	// keep decoding after values that cannot be decoded
+	collectErrors bool
+
+	// resolves the variables referenced in basic strings when not nil
+	lookupVariable func(string) (string, bool)
+
+	// name of the document, reported in errors
+	name string
+
+	// concrete types of interfaces, by interface type
+	variants map[reflect.Type]*variants
+
+	// store the tables decoded into interface{} values as *OrderedMap
+	orderedMaps bool
+
+	// source of the documents referenced by include directives, and key of
+	// the directives
+	includeFS  fs.FS
+	includeKey string
+
+	// state of the token-level API
+	tokens *tokenReader
 }
 
 // NewDecoder creates a new Decoder that will read from r.
@@ -64,8 +99,14 @@ func (d *Decoder) DisallowUnknownFields() *Decoder {
 // that don't have a straightfoward TOML representation to provide their own
 // decoding logic.
 //
-// Currently, types can only decode from a single value. Tables and array tables
-// are not supported.
+// Types stored in a table or in an element of an array table decode the whole
+// table: UnmarshalTOML is given an InlineTable node containing its key-values
+// as well as the sub-tables defined by the headers that follow it, such as
+// [table.sub] or [[table.items]]. Sub-tables defined after the header of an
+// unrelated table are given to a separate call to UnmarshalTOML. The keys of
+// the table are not reported in the document metadata, and the nodes do not
+// reference the document: their Raw ranges cannot be used with the parser of
+// the document.
 //
 // *Unstable:* This method does not follow the compatibility guarantees of
 // semver. It can be changed or removed without a new major version being
@@ -75,6 +116,111 @@ func (d *Decoder) EnableUnmarshalerInterface() *Decoder {
 	return d
 }
 
+// EnableStreaming makes the Decoder read and decode the document one top-level
+// expression at a time, instead of reading the whole input in memory first.
+//
+// Only the current expression and a few lines of context before it are kept
+// in memory, which allows to decode very large documents. As a result, errors
+// can only show the lines of the document preceding the error, in addition to
+// the expression that caused it.
+//
+// When streaming, the bytes given to encoding.TextUnmarshaler and
+// unstable.Unmarshaler implementations are reused once they return.
+func (d *Decoder) EnableStreaming() *Decoder {
+	d.streaming = true
+	return d
+}
+
+// CollectErrors makes Decode keep going when a value or a key of the document
+// cannot be decoded into the target, for example because of a type mismatch or
+// because a number is out of range. The offending key-value is skipped, leaving
+// the target unchanged, as are the key-values of a table that cannot be
+// decoded. Once the whole document has been processed, all the errors are
+// returned in a DecodeErrors.
+//
+// Syntax errors and redefined keys still stop the decoding. When errors were
+// collected before, the error that stopped the decoding is the last one of the
+// DecodeErrors.
+func (d *Decoder) CollectErrors() *Decoder {
+	d.collectErrors = true
+	return d
+}
+
+// ExpandVariables makes Decode replace the ${NAME} and ${NAME:-default}
+// references contained in basic strings with the value returned by lookup. If
+// lookup is nil, os.LookupEnv is used. The default value is used when the
+// variable is not defined or empty. Use $${ to write a literal ${. References
+// written with escape sequences, such as \u0024{NAME}, are not replaced.
+//
+// Literal strings and keys are not expanded. A reference to an undefined
+// variable without a default value results in a DecodeError pointing at the
+// string.
+func (d *Decoder) ExpandVariables(lookup func(name string) (string, bool)) *Decoder {
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	d.lookupVariable = lookup
+	return d
+}
+
+// RecordMetadata makes Decode fill md with the keys found in the document:
+// their position, and whether they have been stored in the target value. It
+// allows to distinguish a field absent from the document from a field set to
+// its zero value.
+//
+// md is reset at the beginning of each call to Decode.
+func (d *Decoder) RecordMetadata(md *Metadata) *Decoder {
+	d.metadata = md
+	return d
+}
+
+// SetSpecVersion selects the version of the TOML specification the document is
+// decoded against. Defaults to TOML10.
+//
+// With TOML11, inline tables can span multiple lines, contain comments, and
+// end with a trailing comma. Basic strings accept \xHH escape sequences, and
+// the seconds of times are optional.
+func (d *Decoder) SetSpecVersion(v SpecVersion) *Decoder {
+	d.spec = v
+	return d
+}
+
+// SetDocumentName names the document being decoded, typically after its file
+// path. The name is reported by the DecodeErrors returned by Decode, and is
+// used to resolve the relative paths of included documents.
+func (d *Decoder) SetDocumentName(name string) *Decoder {
+	d.name = name
+	return d
+}
+
+// EnableIncludes makes Decode process include directives: key-values named key
+// whose value is the path of a document, or an array of paths. The directive
+// is not decoded into the target. Instead, the documents it references are
+// read from fsys, and decoded in order into the table containing the
+// directive, as if their content was written in that table. For example:
+//
+//	[database]
+//	include = ["database.toml"]
+//
+// decodes the content of database.toml into the database table.
+//
+// Paths are slash-separated. Relative paths are resolved from the directory of
+// the document containing the directive (see SetDocumentName), and absolute
+// paths from the root of fsys. Included documents can include other
+// documents; a document including itself, directly or not, results in an
+// error. Errors in included documents name the document they occurred in.
+//
+// As for a single document, defining a key twice results in an error, even
+// when the definitions are in different documents. Table headers of included
+// documents are relative to the table containing the directive.
+//
+// Keys of included documents are not recorded in the Metadata.
+func (d *Decoder) EnableIncludes(fsys fs.FS, key string) *Decoder {
+	d.includeFS = fsys
+	d.includeKey = key
+	return d
+}
+
 // Decode the whole content of r into v.
 //
 // By default, values in the document that don't exist in the target Go value
@@ -95,6 +241,25 @@ func (d *Decoder) EnableUnmarshalerInterface() *Decoder {
 // bounds for the target type (which includes negative numbers when decoding
 // into an unsigned int).
 //
+// Keys of maps can be strings, types implementing encoding.TextUnmarshaler,
+// integers, or bools. Integer keys are parsed as decimal numbers, and bool keys
+// must be true or false. Keys that cannot be converted result in a DecodeError.
+//
+// Struct fields missing from the document can be given a default value with a
+// `default` tag, written as a TOML value. For example:
+//
+//	Ports []int `default:"[80, 443]"`
+//
+// Default values are applied once the document has been decoded, to fields
+// that still hold their zero value, including the fields of nested structs and
+// of array table elements.
+//
+// Struct fields with the required option, as in `toml:"name,required"`, must
+// be present in the document. Otherwise, Decode returns a RequiredMissingError
+// listing all the missing fields once the document has been decoded. When
+// fields are missing in strict mode as well, both errors are returned in a
+// DecodeErrors.
+//
 // If an error occurs while decoding the content of the document, this function
 // returns a toml.DecodeError, providing context about the issue. When using
 // strict mode and a field is missing, a `toml.StrictMissingError` is
@@ -117,19 +282,46 @@ func (d *Decoder) EnableUnmarshalerInterface() *Decoder {
 //	Inline Table     -> same as Table
 //	Array of Tables  -> same as Array and Table
 func (d *Decoder) Decode(v interface{}) error {
-	b, err := ioutil.ReadAll(d.r)
-	if err != nil {
-		return fmt.Errorf("toml: %w", err)
+	if d.tokens != nil {
+		return fmt.Errorf("toml: Decode cannot be called after Token")
 	}
 
-	p := unstable.Parser{}
-	p.Reset(b)
+	p := unstable.Parser{SpecVersion: d.spec, Origin: d.name}
 	dec := decoder{
 		p: &p,
 		strict: strict{
-			Enabled: d.strict,
+			Enabled:  d.strict,
+			Metadata: d.metadata,
+			Source:   d.name,
 		},
 		unmarshalerInterface: d.unmarshalerInterface,
+		collectErrors:        d.collectErrors,
+		lookupVariable:       d.lookupVariable,
+		variants:             d.variants,
+		orderedMaps:          d.orderedMaps,
+	}
+
+	if d.includeFS != nil {
+		dec.includes = &includes{
+			fsys: d.includeFS,
+			key:  d.includeKey,
+		}
+	}
+
+	if d.metadata != nil {
+		d.metadata.reset()
+	}
+
+	if d.streaming {
+		dec.stream = newExprReader(d.r)
+		dec.seen.CopyNames = true
+	} else {
+		b, err := ioutil.ReadAll(d.r)
+		if err != nil {
+			return fmt.Errorf("toml: %w", err)
+		}
+		p.Reset(b)
+		dec.strict.SetChunk(b, 0, 1)
 	}
 
 	return dec.FromParser(v)
@@ -165,11 +357,77 @@ type decoder struct {
 	// Strict mode
 	strict strict
 
+	// Keys of the document, used to fill the missing fields that have a
+	// default value and to find the missing required fields.
+	fieldTags fieldTags
+
 	// Flag that enables/disables unmarshaler interface.
 	unmarshalerInterface bool
 
 	// Current context for the error.
 	errorContext *errorContext
+
+	// Key of the last table or array table header, and key-value expressions
+	// being decoded, used to report the key of errors.
+	tableKey  tracker.KeyTracker
+	keyValues []*unstable.Node
+
+	// Source of expressions when streaming. Nil when the whole document is
+	// given to the parser.
+	stream *exprReader
+
+	// When enabled, values that cannot be decoded are skipped and their
+	// contextualized errors are collected in errors.
+	collectErrors bool
+	errors        []DecodeError
+
+	// Resolves the variables referenced in basic strings when not nil.
+	lookupVariable func(string) (string, bool)
+
+	// Processes include directives when not nil.
+	includes *includes
+
+	// Concrete types of interfaces, by interface type.
+	variants map[reflect.Type]*variants
+
+	// Store the tables decoded into interface{} values as *OrderedMap.
+	orderedMaps bool
+
+	// Keys of the document that designate the keys of integer maps.
+	mapKeys map[mapKey]string
+
+	// Reports the errors in the document of a captured table, when the
+	// decoder reads the text of that table.
+	captured *capturedErrors
+}
+
+// nested returns a decoder reading p, a document or a value decoded as part of
+// the one of d, with the same options as d.
+func (d *decoder) nested(p *unstable.Parser) decoder {
+	return decoder{
+		p: p,
+		strict: strict{
+			Enabled:  d.strict.Enabled,
+			Metadata: d.strict.Metadata,
+			Source:   d.strict.Source,
+		},
+		fieldTags: fieldTags{
+			Enabled: d.fieldTags.Enabled,
+		},
+		unmarshalerInterface: d.unmarshalerInterface,
+		collectErrors:        d.collectErrors,
+		lookupVariable:       d.lookupVariable,
+		includes:             d.includes,
+		variants:             d.variants,
+		orderedMaps:          d.orderedMaps,
+		mapKeys:              d.mapKeys,
+	}
+}
+
+// mapKey identifies a key of a Go map.
+type mapKey struct {
+	m uintptr
+	k interface{}
 }
 
 type errorContext struct {
@@ -177,6 +435,79 @@ type errorContext struct {
 	Field  []int
 }
 
+// errorKey returns the key of the value being decoded.
+func (d *decoder) errorKey() Key {
+	key := Key(d.tableKey.Key())
+	for _, kv := range d.keyValues {
+		it := kv.Key()
+		for it.Next() {
+			key = append(key, string(it.Node().Data))
+		}
+	}
+	return key
+}
+
+// typeMismatch returns the error of a TOML value of the given kind that cannot
+// be stored in a Go value of type target.
+func (d *decoder) typeMismatch(highlight []byte, toml string, target reflect.Type) error {
+	return withErrorDetails(unstable.NewParserError(highlight, "%s", d.typeMismatchString(toml, target)), CodeTypeMismatch, toml, target)
+}
+
+// duplicateKeyError returns err, reported by the seen tracker about expr, as
+// an error referencing the key of expr.
+func (d *decoder) duplicateKeyError(expr *unstable.Node, err error) error {
+	var key Key
+	if expr.Kind == unstable.KeyValue {
+		key = d.errorKey()
+	}
+	it := expr.Key()
+	for it.Next() {
+		key = append(key, string(it.Node().Data))
+	}
+
+	perr := &unstable.ParserError{
+		Highlight: keyLocation(expr),
+		Message:   strings.TrimPrefix(err.Error(), "toml: "),
+		Key:       key,
+	}
+	found := tomlKind(expr.Kind)
+	if expr.Kind == unstable.KeyValue {
+		found = tomlKind(expr.Value().Kind)
+	}
+	return withErrorDetails(perr, CodeDuplicateKey, found, nil)
+}
+
+// tomlKind returns the name of the kind of TOML value k.
+func tomlKind(k unstable.Kind) string {
+	switch k {
+	case unstable.String:
+		return "string"
+	case unstable.Bool:
+		return "boolean"
+	case unstable.Float:
+		return "float"
+	case unstable.Integer:
+		return "integer"
+	case unstable.LocalDate:
+		return "local date"
+	case unstable.LocalTime:
+		return "local time"
+	case unstable.LocalDateTime:
+		return "local date-time"
+	case unstable.DateTime:
+		return "offset date-time"
+	case unstable.Array:
+		return "array"
+	case unstable.InlineTable:
+		return "inline table"
+	case unstable.Table:
+		return "table"
+	case unstable.ArrayTable:
+		return "array table"
+	}
+	return k.String()
+}
+
 func (d *decoder) typeMismatchError(toml string, target reflect.Type) error {
 	return fmt.Errorf("toml: %s", d.typeMismatchString(toml, target))
 }
@@ -199,7 +530,40 @@ func (d *decoder) nextExpr() bool {
 		d.stashedExpr = false
 		return true
 	}
-	return d.p.NextExpression()
+	if d.p.NextExpression() {
+		return true
+	}
+	if d.stream == nil || d.p.Error() != nil {
+		return false
+	}
+
+	for {
+		// Errors referencing the current chunk need to be contextualized
+		// before it is discarded.
+		d.strict.Flush(d.document())
+
+		if !d.stream.Next() {
+			return false
+		}
+		d.p.Reset(d.stream.Chunk())
+		offset, line := d.stream.ChunkPosition()
+		d.strict.SetChunk(d.stream.Chunk(), offset, line)
+		if d.p.NextExpression() {
+			return true
+		}
+		if d.p.Error() != nil {
+			return false
+		}
+	}
+}
+
+// document returns the bytes of the document currently available, as well as
+// the line number and the offset of its first byte.
+func (d *decoder) document() ([]byte, int, int) {
+	if d.stream != nil {
+		return d.stream.Window(), d.stream.Line(), d.stream.Offset()
+	}
+	return d.p.Data(), 1, 0
 }
 
 func (d *decoder) stashExpr() {
@@ -235,31 +599,99 @@ func (d *decoder) FromParser(v interface{}) error {
 
 	r = r.Elem()
 	if r.Kind() == reflect.Interface && r.IsNil() {
-		newMap := map[string]interface{}{}
-		r.Set(reflect.ValueOf(newMap))
+		r.Set(d.makeTable())
 	}
 
+	d.fieldTags.Enabled = typeHasFieldTags(r.Type())
+
 	err := d.fromParser(r)
+	if err == nil && d.fieldTags.Enabled {
+		err = d.checkFields(r, d.fieldTags.root, "", nil)
+		if err != nil {
+			return err
+		}
+	}
 	if err == nil {
-		return d.strict.Error(d.p.Data())
+		err = d.strict.Error(d.document())
+		required := d.fieldTags.Error()
+		if len(d.errors) > 0 || (err != nil && required != nil) {
+			derrs := &DecodeErrors{Errors: d.errors}
+			errors.As(err, &derrs.Missing)
+			errors.As(required, &derrs.Required)
+			return derrs
+		}
+		if err == nil {
+			err = required
+		}
+		return err
 	}
 
 	var e *unstable.ParserError
 	if errors.As(err, &e) {
-		return wrapDecodeError(d.p.Data(), e)
+		derr := d.wrapError(err, e)
+		if len(d.errors) > 0 {
+			return &DecodeErrors{Errors: append(d.errors, *derr)}
+		}
+		return derr
 	}
 
 	return err
 }
 
+// wrapError contextualizes err, whose ParserError is perr, referencing the
+// current document.
+func (d *decoder) wrapError(err error, perr *unstable.ParserError) *DecodeError {
+	if d.captured != nil {
+		if perr.Key == nil {
+			perr.Key = d.errorKey()
+		}
+		return d.captured.wrapError(err, perr)
+	}
+
+	doc, line, offset := d.document()
+	return d.wrapErrorAt(doc, line, offset, err, perr)
+}
+
+// wrapErrorAt is the same as wrapError, but perr references doc, a part of the
+// document starting at the given line and offset that may not be available
+// anymore.
+func (d *decoder) wrapErrorAt(doc []byte, line, offset int, err error, perr *unstable.ParserError) *DecodeError {
+	if perr.Key == nil {
+		perr.Key = d.errorKey()
+	}
+
+	derr := wrapDecodeErrorAt(doc, line, offset, perr)
+	derr.source = d.p.Origin
+
+	var details *errorDetails
+	if errors.As(err, &details) {
+		derr.code = details.code
+		derr.found = details.found
+		derr.expected = details.expected
+	}
+
+	return derr
+}
+
 func (d *decoder) fromParser(root reflect.Value) error {
 	for d.nextExpr() {
-		err := d.handleRootExpression(d.expr(), root)
+		expr := d.expr()
+		err := d.handleRootExpression(expr, root)
 		if err != nil {
-			return err
+			if !d.collectError(err) {
+				return err
+			}
+			// The key-values of a table that cannot be decoded are skipped.
+			if expr.Kind == unstable.Table || expr.Kind == unstable.ArrayTable {
+				d.skipUntilTable = true
+			}
 		}
 	}
 
+	if d.stream != nil && d.stream.Err() != nil {
+		return fmt.Errorf("toml: %w", d.stream.Err())
+	}
+
 	return d.p.Error()
 }
 
@@ -277,18 +709,35 @@ func (d *decoder) handleRootExpression(expr *unstable.Node, v reflect.Value) err
 	var err error
 	var first bool // used for to clear array tables on first use
 
-	if !(d.skipUntilTable && expr.Kind == unstable.KeyValue) {
+	if expr.Kind == unstable.Table || expr.Kind == unstable.ArrayTable {
+		d.tableKey.UpdateTable(expr)
+	}
+
+	// Include directives are not part of the decoded document, and included
+	// documents can have their own.
+	directive := d.includes.IsDirective(expr)
+
+	if !directive && !(d.skipUntilTable && expr.Kind == unstable.KeyValue) {
 		first, err = d.seen.CheckExpression(expr)
 		if err != nil {
-			return err
+			return d.duplicateKeyError(expr, err)
 		}
 	}
 
+	if !directive {
+		d.fieldTags.Record(expr)
+	}
+
 	switch expr.Kind {
 	case unstable.KeyValue:
 		if d.skipUntilTable {
+			d.strict.SkippedKeyValue(expr)
 			return nil
 		}
+		if directive {
+			x, err = d.handleInclude(expr, v)
+			break
+		}
 		x, err = d.handleKeyValue(expr, v)
 	case unstable.Table:
 		d.skipUntilTable = false
@@ -318,6 +767,10 @@ func (d *decoder) handleArrayTable(key unstable.Iterator, v reflect.Value) (refl
 	if key.Next() {
 		return d.handleArrayTablePart(key, v)
 	}
+	ok, err := d.tryCapturedTable(key, v)
+	if ok || err != nil {
+		return reflect.Value{}, err
+	}
 	return d.handleKeyValues(v)
 }
 
@@ -366,8 +819,8 @@ func (d *decoder) handleArrayTableCollectionLast(key unstable.Iterator, v reflec
 		}
 		elemType := v.Type().Elem()
 		var elem reflect.Value
-		if elemType.Kind() == reflect.Interface {
-			elem = makeMapStringInterface()
+		if elemType.Kind() == reflect.Interface && d.variants[elemType] == nil {
+			elem = d.makeTable()
 		} else {
 			elem = reflect.New(elemType).Elem()
 		}
@@ -401,6 +854,11 @@ func (d *decoder) handleArrayTableCollection(key unstable.Iterator, v reflect.Va
 		return d.handleArrayTableCollectionLast(key, v)
 	}
 
+	ok, err := d.tryCapturedTable(key, v)
+	if ok || err != nil {
+		return reflect.Value{}, err
+	}
+
 	switch v.Kind() {
 	case reflect.Ptr:
 		elem := v.Elem()
@@ -421,6 +879,10 @@ func (d *decoder) handleArrayTableCollection(key unstable.Iterator, v reflect.Va
 		return v, nil
 	case reflect.Slice:
 		elem := v.Index(v.Len() - 1)
+		ok, err := d.tryCapturedTable(key, elem)
+		if ok || err != nil {
+			return v, err
+		}
 		x, err := d.handleArrayTable(key, elem)
 		if err != nil || d.skipUntilTable {
 			return reflect.Value{}, err
@@ -436,7 +898,11 @@ func (d *decoder) handleArrayTableCollection(key unstable.Iterator, v reflect.Va
 			return v, fmt.Errorf("%s at position %d", d.typeMismatchError("array table", v.Type()), idx)
 		}
 		elem := v.Index(idx)
-		_, err := d.handleArrayTable(key, elem)
+		ok, err := d.tryCapturedTable(key, elem)
+		if ok || err != nil {
+			return v, err
+		}
+		_, err = d.handleArrayTable(key, elem)
 		return v, err
 	}
 
@@ -460,7 +926,7 @@ func (d *decoder) handleKeyPart(key unstable.Iterator, v reflect.Value, nextFn h
 		vt := v.Type()
 
 		// Create the key for the map element. Convert to key type.
-		mk, err := d.keyFromData(vt.Key(), key.Node().Data)
+		mk, err := d.keyFromData(vt.Key(), key.Node())
 		if err != nil {
 			return reflect.Value{}, err
 		}
@@ -472,6 +938,11 @@ func (d *decoder) handleKeyPart(key unstable.Iterator, v reflect.Value, nextFn h
 			rv = v
 		}
 
+		err = d.checkMapKey(v, mk, key.Node())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
 		mv := v.MapIndex(mk)
 		set := false
 		if !mv.IsValid() {
@@ -481,7 +952,7 @@ func (d *decoder) handleKeyPart(key unstable.Iterator, v reflect.Value, nextFn h
 			// this is the last part of the array table key.
 
 			t := vt.Elem()
-			if t.Kind() == reflect.Interface {
+			if t.Kind() == reflect.Interface && d.variants[t] == nil {
 				mv = makeFn()
 			} else {
 				mv = reflect.New(t).Elem()
@@ -516,6 +987,10 @@ func (d *decoder) handleKeyPart(key unstable.Iterator, v reflect.Value, nextFn h
 			v.SetMapIndex(mk, mv)
 		}
 	case reflect.Struct:
+		if v.Type() == orderedMapType {
+			return d.handleOrderedMapPart(key, v.Addr().Interface().(*OrderedMap), nextFn, makeFn)
+		}
+
 		path, found := structFieldPath(v, string(key.Node().Data))
 		if !found {
 			d.skipUntilTable = true
@@ -543,7 +1018,7 @@ func (d *decoder) handleKeyPart(key unstable.Iterator, v reflect.Value, nextFn h
 		if v.Elem().IsValid() {
 			v = v.Elem()
 		} else {
-			v = makeMapStringInterface()
+			v = d.makeTable()
 		}
 
 		x, err := d.handleKeyPart(key, v, nextFn, makeFn)
@@ -569,7 +1044,7 @@ func (d *decoder) handleArrayTablePart(key unstable.Iterator, v reflect.Value) (
 	if key.IsLast() {
 		makeFn = makeSliceInterface
 	} else {
-		makeFn = makeMapStringInterface
+		makeFn = d.makeTable
 	}
 	return d.handleKeyPart(key, v, d.handleArrayTableCollection, makeFn)
 }
@@ -579,10 +1054,10 @@ func (d *decoder) handleArrayTablePart(key unstable.Iterator, v reflect.Value) (
 func (d *decoder) handleTable(key unstable.Iterator, v reflect.Value) (reflect.Value, error) {
 	if v.Kind() == reflect.Slice {
 		if v.Len() == 0 {
-			return reflect.Value{}, unstable.NewParserError(key.Node().Data, "cannot store a table in a slice")
+			return reflect.Value{}, withErrorDetails(unstable.NewParserError(key.Node().Data, "cannot store a table in a slice"), CodeTypeMismatch, "table", v.Type())
 		}
 		elem := v.Index(v.Len() - 1)
-		x, err := d.handleTable(key, elem)
+		x, err := d.handleSubTable(key, elem)
 		if err != nil {
 			return reflect.Value{}, err
 		}
@@ -615,14 +1090,24 @@ func (d *decoder) handleKeyValues(v reflect.Value) (reflect.Value, error) {
 			break
 		}
 
-		_, err := d.seen.CheckExpression(expr)
-		if err != nil {
-			return reflect.Value{}, err
-		}
+		var x reflect.Value
+		var err error
+		if d.includes.IsDirective(expr) {
+			x, err = d.handleInclude(expr, v)
+		} else {
+			_, err = d.seen.CheckExpression(expr)
+			if err != nil {
+				return reflect.Value{}, d.duplicateKeyError(expr, err)
+			}
 
-		x, err := d.handleKeyValue(expr, v)
+			d.fieldTags.Record(expr)
+			x, err = d.handleKeyValue(expr, v)
+		}
 		if err != nil {
-			return reflect.Value{}, err
+			if !d.collectError(err) {
+				return reflect.Value{}, err
+			}
+			continue
 		}
 		if x.IsValid() {
 			v = x
@@ -646,7 +1131,17 @@ func makeSliceInterface() reflect.Value {
 }
 
 func (d *decoder) handleTablePart(key unstable.Iterator, v reflect.Value) (reflect.Value, error) {
-	return d.handleKeyPart(key, v, d.handleTable, makeMapStringInterface)
+	return d.handleKeyPart(key, v, d.handleSubTable, d.makeTable)
+}
+
+// handleSubTable is handleTable for the value designated by the parts of the
+// key consumed so far, which may decode the rest of the table itself.
+func (d *decoder) handleSubTable(key unstable.Iterator, v reflect.Value) (reflect.Value, error) {
+	ok, err := d.tryCapturedTable(key, v)
+	if ok || err != nil {
+		return reflect.Value{}, err
+	}
+	return d.handleTable(key, v)
 }
 
 func (d *decoder) tryTextUnmarshaler(node *unstable.Node, v reflect.Value) (bool, error) {
@@ -656,10 +1151,19 @@ func (d *decoder) tryTextUnmarshaler(node *unstable.Node, v reflect.Value) (bool
 		return false, nil
 	}
 
+	// Same for the local date and time types when decoded from their TOML
+	// counterpart, so that the version of the specification is respected.
+	switch {
+	case node.Kind == unstable.LocalDate && v.Type() == localDateType,
+		node.Kind == unstable.LocalTime && v.Type() == localTimeType,
+		node.Kind == unstable.LocalDateTime && v.Type() == localDateTimeType:
+		return false, nil
+	}
+
 	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
 		err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(node.Data)
 		if err != nil {
-			return false, unstable.NewParserError(d.p.Raw(node.Raw), "%w", err)
+			return false, withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "%w", err), CodeOther, tomlKind(node.Kind), v.Type())
 		}
 
 		return true, nil
@@ -668,11 +1172,53 @@ func (d *decoder) tryTextUnmarshaler(node *unstable.Node, v reflect.Value) (bool
 	return false, nil
 }
 
+// collectError adds err to the collected errors and returns true if errors are
+// collected and err does not prevent decoding the rest of the document. Keys
+// redefined in the document and errors without a location stop the decoding.
+func (d *decoder) collectError(err error) bool {
+	if !d.collectErrors {
+		return false
+	}
+
+	var details *errorDetails
+	if !errors.As(err, &details) || details.code == CodeDuplicateKey {
+		return false
+	}
+
+	var perr *unstable.ParserError
+	if !errors.As(err, &perr) {
+		return false
+	}
+
+	d.errors = append(d.errors, *d.wrapError(err, perr))
+	d.strict.Undecoded()
+	return true
+}
+
 func (d *decoder) handleValue(value *unstable.Node, v reflect.Value) error {
+	err := d.handleValueInner(value, v)
+	if err != nil && d.collectError(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *decoder) handleValueInner(value *unstable.Node, v reflect.Value) error {
 	for v.Kind() == reflect.Ptr {
 		v = initAndDereferencePointer(v)
 	}
 
+	if d.lookupVariable != nil && value.Kind == unstable.String {
+		err := d.expandVariables(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	if vs := d.tableVariants(v); vs != nil {
+		return d.unmarshalVariant(vs, value, v)
+	}
+
 	if d.unmarshalerInterface {
 		if v.CanAddr() && v.Addr().CanInterface() {
 			if outi, ok := v.Addr().Interface().(unstable.Unmarshaler); ok {
@@ -745,9 +1291,7 @@ func (d *decoder) unmarshalArray(array *unstable.Node, v reflect.Value) error {
 		v.Set(elem)
 		return nil
 	default:
-		// TODO: use newDecodeError, but first the parser needs to fill
-		//   array.Data.
-		return d.typeMismatchError("array", v.Type())
+		return d.typeMismatch(d.p.Raw(array.Extent())[:1], "array", v.Type())
 	}
 
 	elemType := v.Type().Elem()
@@ -795,12 +1339,12 @@ func (d *decoder) unmarshalInlineTable(itable *unstable.Node, v reflect.Value) e
 	case reflect.Interface:
 		elem := v.Elem()
 		if !elem.IsValid() {
-			elem = makeMapStringInterface()
+			elem = d.makeTable()
 			v.Set(elem)
 		}
-		return d.unmarshalInlineTable(itable, elem)
+		return d.unmarshalInlineTable(itable, reflect.Indirect(elem))
 	default:
-		return unstable.NewParserError(d.p.Raw(itable.Raw), "cannot store inline table in Go type %s", v.Kind())
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(itable.Raw), "cannot store inline table in Go type %s", v.Kind()), CodeTypeMismatch, "inline table", v.Type())
 	}
 
 	it := itable.Children()
@@ -809,6 +1353,9 @@ func (d *decoder) unmarshalInlineTable(itable *unstable.Node, v reflect.Value) e
 
 		x, err := d.handleKeyValue(n, v)
 		if err != nil {
+			if d.collectError(err) {
+				continue
+			}
 			return err
 		}
 		if x.IsValid() {
@@ -820,7 +1367,7 @@ func (d *decoder) unmarshalInlineTable(itable *unstable.Node, v reflect.Value) e
 }
 
 func (d *decoder) unmarshalDateTime(value *unstable.Node, v reflect.Value) error {
-	dt, err := parseDateTime(value.Data)
+	dt, err := parseDateTime(value.Data, d.p.SpecVersion >= unstable.TOML11)
 	if err != nil {
 		return err
 	}
@@ -847,7 +1394,7 @@ func (d *decoder) unmarshalLocalDate(value *unstable.Node, v reflect.Value) erro
 }
 
 func (d *decoder) unmarshalLocalTime(value *unstable.Node, v reflect.Value) error {
-	lt, rest, err := parseLocalTime(value.Data)
+	lt, rest, err := parseLocalTime(value.Data, d.p.SpecVersion >= unstable.TOML11)
 	if err != nil {
 		return err
 	}
@@ -861,7 +1408,7 @@ func (d *decoder) unmarshalLocalTime(value *unstable.Node, v reflect.Value) erro
 }
 
 func (d *decoder) unmarshalLocalDateTime(value *unstable.Node, v reflect.Value) error {
-	ldt, rest, err := parseLocalDateTime(value.Data)
+	ldt, rest, err := parseLocalDateTime(value.Data, d.p.SpecVersion >= unstable.TOML11)
 	if err != nil {
 		return err
 	}
@@ -891,7 +1438,7 @@ func (d *decoder) unmarshalBool(value *unstable.Node, v reflect.Value) error {
 	case reflect.Interface:
 		v.Set(reflect.ValueOf(b))
 	default:
-		return unstable.NewParserError(value.Data, "cannot assign boolean to a %t", b)
+		return withErrorDetails(unstable.NewParserError(value.Data, "cannot assign boolean to a %t", b), CodeTypeMismatch, "boolean", v.Type())
 	}
 
 	return nil
@@ -908,13 +1455,13 @@ func (d *decoder) unmarshalFloat(value *unstable.Node, v reflect.Value) error {
 		v.SetFloat(f)
 	case reflect.Float32:
 		if f > math.MaxFloat32 {
-			return unstable.NewParserError(value.Data, "number %f does not fit in a float32", f)
+			return withErrorDetails(unstable.NewParserError(value.Data, "number %f does not fit in a float32", f), CodeOverflow, "float", v.Type())
 		}
 		v.SetFloat(f)
 	case reflect.Interface:
 		v.Set(reflect.ValueOf(f))
 	default:
-		return unstable.NewParserError(value.Data, "float cannot be assigned to %s", v.Kind())
+		return withErrorDetails(unstable.NewParserError(value.Data, "float cannot be assigned to %s", v.Kind()), CodeTypeMismatch, "float", v.Type())
 	}
 
 	return nil
@@ -948,6 +1495,10 @@ func (d *decoder) unmarshalInteger(value *unstable.Node, v reflect.Value) error
 
 	i, err := parseInteger(value.Data)
 	if err != nil {
+		var details *errorDetails
+		if errors.As(err, &details) {
+			details.expected = v.Type()
+		}
 		return err
 	}
 
@@ -959,62 +1510,62 @@ func (d *decoder) unmarshalInteger(value *unstable.Node, v reflect.Value) error
 		return nil
 	case reflect.Int32:
 		if i < math.MinInt32 || i > math.MaxInt32 {
-			return fmt.Errorf("toml: number %d does not fit in an int32", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "number %d does not fit in an int32", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(int32(i))
 	case reflect.Int16:
 		if i < math.MinInt16 || i > math.MaxInt16 {
-			return fmt.Errorf("toml: number %d does not fit in an int16", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "number %d does not fit in an int16", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(int16(i))
 	case reflect.Int8:
 		if i < math.MinInt8 || i > math.MaxInt8 {
-			return fmt.Errorf("toml: number %d does not fit in an int8", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "number %d does not fit in an int8", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(int8(i))
 	case reflect.Int:
 		if i < minInt || i > maxInt {
-			return fmt.Errorf("toml: number %d does not fit in an int", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "number %d does not fit in an int", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(int(i))
 	case reflect.Uint64:
 		if i < 0 {
-			return fmt.Errorf("toml: negative number %d does not fit in an uint64", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "negative number %d does not fit in an uint64", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(uint64(i))
 	case reflect.Uint32:
 		if i < 0 || i > math.MaxUint32 {
-			return fmt.Errorf("toml: negative number %d does not fit in an uint32", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "negative number %d does not fit in an uint32", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(uint32(i))
 	case reflect.Uint16:
 		if i < 0 || i > math.MaxUint16 {
-			return fmt.Errorf("toml: negative number %d does not fit in an uint16", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "negative number %d does not fit in an uint16", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(uint16(i))
 	case reflect.Uint8:
 		if i < 0 || i > math.MaxUint8 {
-			return fmt.Errorf("toml: negative number %d does not fit in an uint8", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "negative number %d does not fit in an uint8", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(uint8(i))
 	case reflect.Uint:
 		if i < 0 || i > maxUint {
-			return fmt.Errorf("toml: negative number %d does not fit in an uint", i)
+			return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "negative number %d does not fit in an uint", i), CodeOverflow, "integer", v.Type())
 		}
 
 		r = reflect.ValueOf(uint(i))
 	case reflect.Interface:
 		r = reflect.ValueOf(i)
 	default:
-		return unstable.NewParserError(d.p.Raw(value.Raw), d.typeMismatchString("integer", v.Type()))
+		return d.typeMismatch(d.p.Raw(value.Raw), "integer", v.Type())
 	}
 
 	if !r.Type().AssignableTo(v.Type()) {
@@ -1033,7 +1584,7 @@ func (d *decoder) unmarshalString(value *unstable.Node, v reflect.Value) error {
 	case reflect.Interface:
 		v.Set(reflect.ValueOf(string(value.Data)))
 	default:
-		return unstable.NewParserError(d.p.Raw(value.Raw), d.typeMismatchString("string", v.Type()))
+		return d.typeMismatch(d.p.Raw(value.Raw), "string", v.Type())
 	}
 
 	return nil
@@ -1041,6 +1592,8 @@ func (d *decoder) unmarshalString(value *unstable.Node, v reflect.Value) error {
 
 func (d *decoder) handleKeyValue(expr *unstable.Node, v reflect.Value) (reflect.Value, error) {
 	d.strict.EnterKeyValue(expr)
+	keyValues := len(d.keyValues)
+	d.keyValues = append(d.keyValues, expr)
 
 	v, err := d.handleKeyValueInner(expr.Key(), expr.Value(), v)
 	if d.skipUntilTable {
@@ -1048,7 +1601,15 @@ func (d *decoder) handleKeyValue(expr *unstable.Node, v reflect.Value) (reflect.
 		d.skipUntilTable = false
 	}
 
+	// The key of the error is resolved while the key-value is still being
+	// processed, as the nodes are not valid anymore once it is done.
+	var perr *unstable.ParserError
+	if errors.As(err, &perr) && perr.Key == nil {
+		perr.Key = d.errorKey()
+	}
+
 	d.strict.ExitKeyValue(expr)
+	d.keyValues = d.keyValues[:keyValues]
 
 	return v, err
 }
@@ -1063,7 +1624,10 @@ func (d *decoder) handleKeyValueInner(key unstable.Iterator, value *unstable.Nod
 	return reflect.Value{}, d.handleValue(value, v)
 }
 
-func (d *decoder) keyFromData(keyType reflect.Type, data []byte) (reflect.Value, error) {
+// keyFromData converts the key part node to a map key of type keyType.
+func (d *decoder) keyFromData(keyType reflect.Type, node *unstable.Node) (reflect.Value, error) {
+	data := node.Data
+
 	switch {
 	case stringType.AssignableTo(keyType):
 		return reflect.ValueOf(string(data)), nil
@@ -1074,18 +1638,79 @@ func (d *decoder) keyFromData(keyType reflect.Type, data []byte) (reflect.Value,
 	case keyType.Implements(textUnmarshalerType):
 		mk := reflect.New(keyType.Elem())
 		if err := mk.Interface().(encoding.TextUnmarshaler).UnmarshalText(data); err != nil {
-			return reflect.Value{}, fmt.Errorf("toml: error unmarshalling key type %s from text: %w", stringType, err)
+			return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "error unmarshalling key type %s from text: %w", keyType, err), CodeOther, "key", keyType)
 		}
 		return mk, nil
 
 	case reflect.PtrTo(keyType).Implements(textUnmarshalerType):
 		mk := reflect.New(keyType)
 		if err := mk.Interface().(encoding.TextUnmarshaler).UnmarshalText(data); err != nil {
-			return reflect.Value{}, fmt.Errorf("toml: error unmarshalling key type %s from text: %w", stringType, err)
+			return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "error unmarshalling key type %s from text: %w", keyType, err), CodeOther, "key", keyType)
 		}
 		return mk.Elem(), nil
 	}
-	return reflect.Value{}, fmt.Errorf("toml: cannot convert map key of type %s to expected type %s", stringType, keyType)
+
+	switch keyType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(string(data), 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, d.keyError(node, keyType, err)
+		}
+		return reflect.ValueOf(i).Convert(keyType), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(string(data), 10, keyType.Bits())
+		if err != nil {
+			return reflect.Value{}, d.keyError(node, keyType, err)
+		}
+		return reflect.ValueOf(u).Convert(keyType), nil
+	case reflect.Bool:
+		switch string(data) {
+		case "true":
+			return reflect.ValueOf(true).Convert(keyType), nil
+		case "false":
+			return reflect.ValueOf(false).Convert(keyType), nil
+		}
+		return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "cannot decode key %q into a map key of type %s", data, keyType), CodeTypeMismatch, "key", keyType)
+	}
+
+	return reflect.Value{}, withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "cannot convert map key of type %s to expected type %s", stringType, keyType), CodeTypeMismatch, "key", keyType)
+}
+
+// checkMapKey returns an error if the key part node designates the key mk of
+// the integer map m, which was designated by a different key of the document
+// before, like 7 and 007.
+func (d *decoder) checkMapKey(m, mk reflect.Value, node *unstable.Node) error {
+	switch mk.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return nil
+	}
+
+	if d.mapKeys == nil {
+		d.mapKeys = make(map[mapKey]string)
+	}
+
+	k := mapKey{m: m.Pointer(), k: mk.Interface()}
+	prev, ok := d.mapKeys[k]
+	if !ok {
+		d.mapKeys[k] = string(node.Data)
+		return nil
+	}
+	if prev == string(node.Data) {
+		return nil
+	}
+
+	return withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "keys %s and %s are the same key of type %s", prev, node.Data, mk.Type()), CodeDuplicateKey, "key", m.Type().Key())
+}
+
+// keyError returns the error of the conversion of the key part node to an
+// integer map key of type keyType.
+func (d *decoder) keyError(node *unstable.Node, keyType reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "key %s does not fit in a map key of type %s", node.Data, keyType), CodeOverflow, "key", keyType)
+	}
+	return withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw), "cannot decode key %q into a map key of type %s", node.Data, keyType), CodeTypeMismatch, "key", keyType)
 }
 
 func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node, v reflect.Value) (reflect.Value, error) {
@@ -1098,7 +1723,7 @@ func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node
 	case reflect.Map:
 		vt := v.Type()
 
-		mk, err := d.keyFromData(vt.Key(), key.Node().Data)
+		mk, err := d.keyFromData(vt.Key(), key.Node())
 		if err != nil {
 			return reflect.Value{}, err
 		}
@@ -1109,6 +1734,11 @@ func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node
 			rv = v
 		}
 
+		err = d.checkMapKey(v, mk, key.Node())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
 		mv := v.MapIndex(mk)
 		set := false
 		if !mv.IsValid() || key.IsLast() {
@@ -1129,6 +1759,10 @@ func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node
 			v.SetMapIndex(mk, mv)
 		}
 	case reflect.Struct:
+		if v.Type() == orderedMapType {
+			return d.handleOrderedMapKeyValuePart(key, value, v.Addr().Interface().(*OrderedMap))
+		}
+
 		path, found := structFieldPath(v, string(key.Node().Data))
 		if !found {
 			d.skipUntilTable = true
@@ -1173,8 +1807,8 @@ func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node
 		// interface{}, it needs to always hold a
 		// map[string]interface{}. This is for the types to be
 		// consistent whether a previous value was set or not.
-		if !v.IsValid() || v.Type() != mapStringInterfaceType {
-			v = makeMapStringInterface()
+		if !v.IsValid() || v.Type() != d.tableType() {
+			v = d.makeTable()
 		}
 
 		x, err := d.handleKeyValuePart(key, value, v)
@@ -1203,7 +1837,7 @@ func (d *decoder) handleKeyValuePart(key unstable.Iterator, value *unstable.Node
 		}
 		v.Elem().Set(elem)
 	default:
-		return reflect.Value{}, fmt.Errorf("unhandled kv part: %s", v.Kind())
+		return reflect.Value{}, d.typeMismatch(d.p.Raw(key.Node().Raw), "table", v.Type())
 	}
 
 	return rv, nil
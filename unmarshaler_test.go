@@ -2134,6 +2134,242 @@ func TestUnmarshalFloat32(t *testing.T) {
 	})
 }
 
+func TestDecoderSetSpecVersion(t *testing.T) {
+	doc := `
+server = {
+  host = "\x41", # comment
+  start = 07:30,
+  at = 2021-07-21T12:08Z,
+}
+`
+
+	type server struct {
+		Host  string
+		Start toml.LocalTime
+		At    time.Time
+	}
+	expected := server{
+		Host:  "A",
+		Start: toml.LocalTime{Hour: 7, Minute: 30},
+		At:    time.Date(2021, 7, 21, 12, 8, 0, 0, time.UTC),
+	}
+
+	var v10 struct{ Server server }
+	err := toml.NewDecoder(strings.NewReader(doc)).Decode(&v10)
+	require.Error(t, err)
+
+	var v11 struct{ Server server }
+	err = toml.NewDecoder(strings.NewReader(doc)).SetSpecVersion(toml.TOML11).Decode(&v11)
+	require.NoError(t, err)
+	assert.Equal(t, expected, v11.Server)
+
+	var streamed struct{ Server server }
+	err = toml.NewDecoder(strings.NewReader(doc)).SetSpecVersion(toml.TOML11).EnableStreaming().Decode(&streamed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, streamed.Server)
+
+	err = toml.NewDecoder(strings.NewReader("a = 07:30")).SetSpecVersion(toml.TOML11).Decode(&struct{ A toml.LocalTime }{})
+	require.NoError(t, err)
+	err = toml.NewDecoder(strings.NewReader("a = 07:30")).Decode(&struct{ A toml.LocalTime }{})
+	require.Error(t, err)
+}
+
+func TestDecoderCollectErrors(t *testing.T) {
+	doc := `name = 42
+port = 70000
+tags = ["a", 2, "c"]
+ok = true
+
+[limits]
+max = "high"
+ratio = 0.5
+`
+
+	type config struct {
+		Name   string
+		Port   uint16
+		Tags   []string
+		OK     bool
+		Limits struct {
+			Max   int
+			Ratio float64
+		}
+	}
+
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%t", streaming), func(t *testing.T) {
+			var cfg config
+			d := toml.NewDecoder(strings.NewReader(doc)).CollectErrors()
+			if streaming {
+				d.EnableStreaming()
+			}
+			err := d.Decode(&cfg)
+			require.Error(t, err)
+
+			var derrs *toml.DecodeErrors
+			require.True(t, errors.As(err, &derrs), "%T: %s", err, err)
+			assert.Equal(t, "toml: 4 values could not be decoded", derrs.Error())
+			assert.Nil(t, derrs.Missing)
+
+			var positions [][2]int
+			for _, e := range derrs.Errors {
+				row, col := e.Position()
+				positions = append(positions, [2]int{row, col})
+			}
+			assert.Equal(t, [][2]int{{1, 8}, {2, 8}, {3, 14}, {7, 7}}, positions)
+
+			// Streaming only shows the lines before the error.
+			assert.True(t, strings.HasPrefix(derrs.Errors[0].String(), `1| name = 42
+ |        ~~ cannot decode TOML integer into struct field toml_test.config.Name of type string`))
+			assert.Contains(t, derrs.String(), "number 70000 does not fit in an uint16")
+
+			// Valid values are still decoded.
+			assert.Equal(t, []string{"a", "", "c"}, cfg.Tags)
+			assert.True(t, cfg.OK)
+			assert.Equal(t, 0.5, cfg.Limits.Ratio)
+		})
+	}
+}
+
+func TestDecoderCollectErrorsSingle(t *testing.T) {
+	var v struct{ A int8 }
+	err := toml.NewDecoder(strings.NewReader("a = 1000")).CollectErrors().Decode(&v)
+
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs))
+	require.Len(t, derrs.Errors, 1)
+	assert.Equal(t, "toml: number 1000 does not fit in an int8", err.Error())
+}
+
+func TestDecoderCollectErrorsStrict(t *testing.T) {
+	var v struct{ A int }
+	err := toml.NewDecoder(strings.NewReader("a = 'x'\nb = 1")).
+		CollectErrors().
+		DisallowUnknownFields().
+		Decode(&v)
+
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs))
+	require.Len(t, derrs.Errors, 1)
+
+	var serr *toml.StrictMissingError
+	require.True(t, errors.As(err, &serr))
+	require.Len(t, serr.Errors, 1)
+	assert.Equal(t, toml.Key{"b"}, serr.Errors[0].Key())
+}
+
+func TestDecoderCollectErrorsSyntax(t *testing.T) {
+	var v struct{ A, B int }
+	err := toml.NewDecoder(strings.NewReader("b = = 1")).CollectErrors().Decode(&v)
+
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%T: %s", err, err)
+	row, _ := derr.Position()
+	assert.Equal(t, 1, row)
+}
+
+func TestDecoderCollectErrorsKeys(t *testing.T) {
+	doc := `a = 'x'
+b = 1
+
+[g]
+foo = 'bar'
+1 = 'one'
+
+[h]
+c = 2
+`
+
+	var v struct {
+		A int
+		B int
+		G map[int]string
+		H int
+	}
+	err := toml.NewDecoder(strings.NewReader(doc)).CollectErrors().Decode(&v)
+
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs), "%T: %s", err, err)
+
+	var keys []toml.Key
+	var codes []toml.ErrorCode
+	for _, e := range derrs.Errors {
+		keys = append(keys, e.Key())
+		codes = append(codes, e.Code())
+	}
+	assert.Equal(t, []toml.Key{{"a"}, {"g", "foo"}, {"h", "c"}}, keys)
+	assert.Equal(t, []toml.ErrorCode{toml.CodeTypeMismatch, toml.CodeTypeMismatch, toml.CodeTypeMismatch}, codes)
+
+	// The rest of the document is still decoded.
+	assert.Equal(t, 1, v.B)
+	assert.Equal(t, map[int]string{1: "one"}, v.G)
+}
+
+func TestDecoderCollectErrorsAfterInlineTable(t *testing.T) {
+	doc := "A = {x = 1, y = {z = 'a'}}\nB = 3\nC = 'z'\n[D]\nE = {F = 1}\n"
+
+	var v struct {
+		A map[int]interface{}
+		B string
+		C int
+		D struct{ E struct{ F string } }
+	}
+	err := toml.NewDecoder(strings.NewReader(doc)).CollectErrors().Decode(&v)
+
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs), "%T: %s", err, err)
+
+	var keys []toml.Key
+	for _, e := range derrs.Errors {
+		keys = append(keys, e.Key())
+	}
+	assert.Equal(t, []toml.Key{{"A", "x"}, {"A", "y"}, {"B"}, {"C"}, {"D", "E", "F"}}, keys)
+}
+
+func TestDecoderCollectErrorsFatal(t *testing.T) {
+	examples := []struct {
+		desc string
+		doc  string
+		code toml.ErrorCode
+		row  int
+	}{
+		{
+			desc: "syntax",
+			doc:  "a = 'x'\n[g]\nfoo = 'bar'\nb = = 1\n",
+			code: toml.CodeSyntax,
+			row:  4,
+		},
+		{
+			desc: "duplicate key",
+			doc:  "a = 'x'\n[g]\nfoo = 'bar'\nfoo = 'baz'\n",
+			code: toml.CodeDuplicateKey,
+			row:  4,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var v struct {
+				A int
+				G map[int]string
+			}
+			err := toml.NewDecoder(strings.NewReader(e.doc)).CollectErrors().Decode(&v)
+
+			var derrs *toml.DecodeErrors
+			require.True(t, errors.As(err, &derrs), "%T: %s", err, err)
+			require.Len(t, derrs.Errors, 3)
+			assert.Equal(t, toml.Key{"a"}, derrs.Errors[0].Key())
+			assert.Equal(t, toml.Key{"g", "foo"}, derrs.Errors[1].Key())
+
+			last := derrs.Errors[2]
+			assert.Equal(t, e.code, last.Code())
+			row, _ := last.Position()
+			assert.Equal(t, e.row, row)
+		})
+	}
+}
+
 func TestDecoderStrict(t *testing.T) {
 	examples := []struct {
 		desc     string
@@ -3865,3 +4101,322 @@ foo = "bar"`,
 		})
 	}
 }
+
+// nodeTable records the key-values of a table given to UnmarshalTOML, in
+// order, as flattened keys.
+type nodeTable []string
+
+func (n *nodeTable) UnmarshalTOML(value *unstable.Node) error {
+	if value.Kind != unstable.InlineTable {
+		return fmt.Errorf("expected a table, not %s", value.Kind)
+	}
+	*n = nil
+	n.add("", value)
+	return nil
+}
+
+func (n *nodeTable) add(prefix string, value *unstable.Node) {
+	switch value.Kind {
+	case unstable.InlineTable:
+		it := value.Children()
+		for it.Next() {
+			kv := it.Node()
+			key := prefix
+			k := kv.Key()
+			for k.Next() {
+				key += string(k.Node().Data) + "."
+			}
+			n.add(key, kv.Value())
+		}
+	case unstable.Array:
+		i := 0
+		it := value.Children()
+		for it.Next() {
+			n.add(prefix+strconv.Itoa(i)+".", it.Node())
+			i++
+		}
+	default:
+		*n = append(*n, strings.TrimSuffix(prefix, ".")+"="+string(value.Data))
+	}
+}
+
+type failingNodeTable struct{}
+
+func (failingNodeTable) UnmarshalTOML(value *unstable.Node) error {
+	return errors.New("cannot decode this table")
+}
+
+func TestUnmarshal_CustomUnmarshalerTables(t *testing.T) {
+	type config struct {
+		Name    string
+		Section nodeTable
+		Ptr     *nodeTable
+		Items   []nodeTable
+		Named   map[string]nodeTable
+		Nested  struct{ Table nodeTable }
+	}
+
+	examples := []struct {
+		desc     string
+		input    string
+		expected config
+	}{
+		{
+			desc: "table",
+			input: `
+name = "a"
+[section]
+b = 1
+a = "x"
+`,
+			expected: config{Name: "a", Section: nodeTable{"b=1", "a=x"}},
+		},
+		{
+			desc: "sub-tables",
+			input: `
+[section]
+a.b = 1
+[section.sub]
+c = true
+[section.a.d]
+e = [1, 2]
+[[section.list]]
+f = 1
+[section.list.g]
+h = 2
+[[section.list]]
+[section.empty]
+[other]
+`,
+			expected: config{Section: nodeTable{"a.b=1", "sub.c=true", "a.d.e.0=1", "a.d.e.1=2", "list.0.f=1", "list.0.g.h=2"}},
+		},
+		{
+			desc: "implicit table",
+			input: `
+[section.sub]
+a = 1
+[section]
+b = 2
+`,
+			expected: config{Section: nodeTable{"b=2", "sub.a=1"}},
+		},
+		{
+			desc: "pointer",
+			input: `
+[ptr]
+a = 1
+`,
+			expected: config{Ptr: &nodeTable{"a=1"}},
+		},
+		{
+			desc: "array tables",
+			input: `
+[[items]]
+a = 1
+[items.sub]
+b = 2
+[[items]]
+c = 3
+[[items.more]]
+d = 4
+`,
+			expected: config{Items: []nodeTable{{"a=1", "sub.b=2"}, {"c=3", "more.0.d=4"}}},
+		},
+		{
+			desc: "map values",
+			input: `
+[named.x]
+a = 1
+[named."y.z"]
+b = 2
+["named"."y.z".sub]
+c = 3
+`,
+			expected: config{Named: map[string]nodeTable{"x": {"a=1"}, "y.z": {"b=2", "sub.c=3"}}},
+		},
+		{
+			desc: "nested table",
+			input: `
+[nested.table]
+a = """
+multi
+"""
+b = [
+  1, # comment
+  2,
+]
+`,
+			expected: config{Nested: struct{ Table nodeTable }{nodeTable{"a=multi\n", "b.0=1", "b.1=2"}}},
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var c config
+			err := toml.NewDecoder(strings.NewReader(e.input)).EnableUnmarshalerInterface().Decode(&c)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, c)
+
+			var streamed config
+			err = toml.NewDecoder(strings.NewReader(e.input)).EnableUnmarshalerInterface().EnableStreaming().Decode(&streamed)
+			require.NoError(t, err)
+			assert.Equal(t, e.expected, streamed)
+		})
+	}
+}
+
+func TestUnmarshal_CustomUnmarshalerTablesErrors(t *testing.T) {
+	type config struct {
+		Section nodeTable
+		Failing failingNodeTable
+	}
+
+	doc := `
+[section]
+a = 1
+[failing]
+b = 2
+[failing.sub]
+c = 3
+`
+
+	var c config
+	err := toml.NewDecoder(strings.NewReader(doc)).EnableUnmarshalerInterface().Decode(&c)
+	var derr *toml.DecodeError
+	require.ErrorAs(t, err, &derr)
+	assert.Equal(t, "toml: cannot decode this table", derr.Error())
+	row, col := derr.Position()
+	assert.Equal(t, 4, row)
+	assert.Equal(t, 1, col)
+
+	c = config{}
+	err = toml.NewDecoder(strings.NewReader(doc)).EnableUnmarshalerInterface().CollectErrors().Decode(&c)
+	var derrs *toml.DecodeErrors
+	require.ErrorAs(t, err, &derrs)
+	require.Len(t, derrs.Errors, 1)
+	assert.Equal(t, nodeTable{"a=1"}, c.Section)
+
+	c = config{}
+	err = toml.NewDecoder(strings.NewReader(doc)).EnableUnmarshalerInterface().EnableStreaming().Decode(&c)
+	require.ErrorAs(t, err, &derr)
+	assert.Equal(t, "toml: cannot decode this table", derr.Error())
+	assert.Equal(t, toml.Key{"failing"}, derr.Key())
+	row, col = derr.Position()
+	assert.Equal(t, 4, row)
+	assert.Equal(t, 1, col)
+	assert.Equal(t, nodeTable{"a=1"}, c.Section)
+
+	c = config{}
+	err = toml.NewDecoder(strings.NewReader("[section]\na = 1\n[section.sub]\na = 1\na = 2\n")).EnableUnmarshalerInterface().Decode(&c)
+	require.Error(t, err)
+}
+
+func TestUnmarshalIntegerAndBoolMapKeys(t *testing.T) {
+	type worker struct {
+		Name string
+	}
+	type config struct {
+		Workers  map[int]worker
+		Ports    map[uint16]string
+		Features map[bool][]string
+		Offsets  map[int8]int
+	}
+
+	doc := `
+ports = { 80 = "http", 443 = "https" }
+offsets.-5 = 1
+offsets.127 = 2
+
+[workers.1]
+name = "a"
+[workers.-2]
+name = "b"
+
+[features]
+true = ["x"]
+false = []
+`
+
+	var c config
+	err := toml.Unmarshal([]byte(doc), &c)
+	require.NoError(t, err)
+	assert.Equal(t, config{
+		Workers:  map[int]worker{1: {Name: "a"}, -2: {Name: "b"}},
+		Ports:    map[uint16]string{80: "http", 443: "https"},
+		Features: map[bool][]string{true: {"x"}, false: {}},
+		Offsets:  map[int8]int{-5: 1, 127: 2},
+	}, c)
+}
+
+func TestUnmarshalIntegerAndBoolMapKeysErrors(t *testing.T) {
+	examples := []struct {
+		desc     string
+		input    string
+		target   interface{}
+		err      string
+		row, col int
+	}{
+		{
+			desc:   "overflow",
+			input:  "1 = 1\n300 = 2",
+			target: &map[uint8]int{},
+			err:    "toml: key 300 does not fit in a map key of type uint8",
+			row:    2,
+			col:    1,
+		},
+		{
+			desc:   "negative unsigned",
+			input:  "-1 = 2",
+			target: &map[uint]int{},
+			err:    `toml: cannot decode key "-1" into a map key of type uint`,
+			row:    1,
+			col:    1,
+		},
+		{
+			desc:   "not an integer",
+			input:  "[table]\n[table.'x']",
+			target: &map[string]map[int]interface{}{},
+			err:    `toml: cannot decode key "x" into a map key of type int`,
+			row:    2,
+			col:    8,
+		},
+		{
+			desc:   "same integer",
+			input:  "7 = 'a'\n007 = 'b'",
+			target: &map[int]string{},
+			err:    "toml: keys 7 and 007 are the same key of type int",
+			row:    2,
+			col:    1,
+		},
+		{
+			desc:   "same integer in tables",
+			input:  "[m.007]\na = 1\n[m.7]\nb = 2",
+			target: &map[string]map[uint]map[string]int{},
+			err:    "toml: keys 007 and 7 are the same key of type uint",
+			row:    3,
+			col:    4,
+		},
+		{
+			desc:   "not a bool",
+			input:  "a = { yes = 1 }",
+			target: &map[string]map[bool]int{},
+			err:    `toml: cannot decode key "yes" into a map key of type bool`,
+			row:    1,
+			col:    7,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			err := toml.Unmarshal([]byte(e.input), e.target)
+			var derr *toml.DecodeError
+			require.ErrorAs(t, err, &derr)
+			assert.Equal(t, e.err, derr.Error())
+			row, col := derr.Position()
+			assert.Equal(t, e.row, row)
+			assert.Equal(t, e.col, col)
+		})
+	}
+}
@@ -65,6 +65,9 @@ type Node struct {
 	Raw  Range  // Raw bytes from the input.
 	Data []byte // Node value (either allocated or referencing the input).
 
+	// Range of the whole construct for nodes that enclose other nodes.
+	extent Range
+
 	// References to other nodes, as offsets in the backing array
 	// from this node. References can go backward, so those can be
 	// negative.
@@ -100,6 +103,17 @@ func (n *Node) Child() *Node {
 	return (*Node)(danger.Stride(ptr, size, n.child))
 }
 
+// Extent returns the range of bytes covering the whole construct the node
+// represents. For Table, ArrayTable, Array, and InlineTable nodes, it goes
+// from the opening bracket or brace to the closing one, while Raw is empty or
+// only covers the opening brace. For other nodes, it is the same as Raw.
+func (n *Node) Extent() Range {
+	if n.extent.Length > 0 {
+		return n.extent
+	}
+	return n.Raw
+}
+
 // Valid returns true if the node's kind is set (not to Invalid).
 func (n *Node) Valid() bool {
 	return n != nil
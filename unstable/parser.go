@@ -35,6 +35,18 @@ func NewParserError(highlight []byte, format string, args ...interface{}) error
 	}
 }
 
+// SpecVersion identifies a version of the TOML specification.
+type SpecVersion int
+
+const (
+	// TOML10 is TOML v1.0.0. It is the default.
+	TOML10 SpecVersion = iota
+	// TOML11 is TOML v1.1.0. It allows newlines, comments, and a trailing
+	// comma in inline tables, \xHH escapes in basic strings, and times
+	// without seconds.
+	TOML11
+)
+
 // Parser scans over a TOML-encoded document and generates an iterative AST.
 //
 // To prime the Parser, first reset it with the contents of a TOML document.
@@ -58,6 +70,16 @@ type Parser struct {
 	first   bool
 
 	KeepComments bool
+
+	// SpecVersion is the version of the TOML specification the document is
+	// parsed against. Defaults to TOML10.
+	SpecVersion SpecVersion
+
+	// Origin identifies the document being parsed, for example by its file
+	// path. It is not used by the parser, but tells consumers of the
+	// expressions which document they come from, for example when documents
+	// include each other.
+	Origin string
 }
 
 // Data returns the slice provided to the last call to Reset.
@@ -268,6 +290,7 @@ func (p *Parser) parseArrayTable(b []byte) (reference, []byte, error) {
 		Kind: ArrayTable,
 	})
 
+	start := b
 	b = b[2:]
 	b = p.parseWhitespace(b)
 
@@ -285,6 +308,9 @@ func (p *Parser) parseArrayTable(b []byte) (reference, []byte, error) {
 	}
 
 	b, err = expect(']', b)
+	if err == nil {
+		p.builder.NodeAt(ref).extent = p.Range(start[:len(start)-len(b)])
+	}
 
 	return ref, b, err
 }
@@ -297,6 +323,7 @@ func (p *Parser) parseStdTable(b []byte) (reference, []byte, error) {
 		Kind: Table,
 	})
 
+	start := b
 	b = b[1:]
 	b = p.parseWhitespace(b)
 
@@ -310,6 +337,9 @@ func (p *Parser) parseStdTable(b []byte) (reference, []byte, error) {
 	b = p.parseWhitespace(b)
 
 	b, err = expect(']', b)
+	if err == nil {
+		p.builder.NodeAt(ref).extent = p.Range(start[:len(start)-len(b)])
+	}
 
 	return ref, b, err
 }
@@ -408,6 +438,7 @@ func (p *Parser) parseVal(b []byte) (reference, []byte, error) {
 		ref = p.builder.Push(Node{
 			Kind: Bool,
 			Data: b[:4],
+			Raw:  p.Range(b[:4]),
 		})
 
 		return ref, b[4:], nil
@@ -419,6 +450,7 @@ func (p *Parser) parseVal(b []byte) (reference, []byte, error) {
 		ref = p.builder.Push(Node{
 			Kind: Bool,
 			Data: b[:5],
+			Raw:  p.Range(b[:5]),
 		})
 
 		return ref, b[5:], nil
@@ -454,6 +486,7 @@ func (p *Parser) parseInlineTable(b []byte) (reference, []byte, error) {
 	// inline-table-close = ws %x7D     ; }
 	// inline-table-sep   = ws %x2C ws  ; , Comma
 	// inline-table-keyvals = keyval [ inline-table-sep inline-table-keyvals ]
+	start := b
 	parent := p.builder.Push(Node{
 		Kind: InlineTable,
 		Raw:  p.Range(b[:1]),
@@ -469,7 +502,10 @@ func (p *Parser) parseInlineTable(b []byte) (reference, []byte, error) {
 
 	for len(b) > 0 {
 		previousB := b
-		b = p.parseWhitespace(b)
+		b, err = p.parseInlineTableWhitespace(b)
+		if err != nil {
+			return parent, nil, err
+		}
 
 		if len(b) == 0 {
 			return parent, nil, NewParserError(previousB[:1], "inline table is incomplete")
@@ -484,7 +520,15 @@ func (p *Parser) parseInlineTable(b []byte) (reference, []byte, error) {
 			if err != nil {
 				return parent, nil, err
 			}
-			b = p.parseWhitespace(b)
+			b, err = p.parseInlineTableWhitespace(b)
+			if err != nil {
+				return parent, nil, err
+			}
+
+			// TOML 1.1 allows a trailing comma.
+			if p.SpecVersion >= TOML11 && len(b) > 0 && b[0] == '}' {
+				break
+			}
 		}
 
 		var kv reference
@@ -505,10 +549,29 @@ func (p *Parser) parseInlineTable(b []byte) (reference, []byte, error) {
 	}
 
 	rest, err := expect('}', b)
+	if err == nil {
+		p.builder.NodeAt(parent).extent = p.Range(start[:len(start)-len(rest)])
+	}
 
 	return parent, rest, err
 }
 
+// parseInlineTableWhitespace skips the whitespace between the elements of an
+// inline table. Starting with TOML 1.1, it may contain new lines and comments.
+// Comments in inline tables are not kept.
+func (p *Parser) parseInlineTableWhitespace(b []byte) ([]byte, error) {
+	if p.SpecVersion < TOML11 {
+		return p.parseWhitespace(b), nil
+	}
+
+	keep := p.KeepComments
+	p.KeepComments = false
+	_, b, err := p.parseOptionalWhitespaceCommentNewline(b)
+	p.KeepComments = keep
+
+	return b, err
+}
+
 //nolint:funlen,cyclop
 func (p *Parser) parseValArray(b []byte) (reference, []byte, error) {
 	// array = array-open [ array-values ] ws-comment-newline array-close
@@ -602,6 +665,9 @@ func (p *Parser) parseValArray(b []byte) (reference, []byte, error) {
 	}
 
 	rest, err := expect(']', b)
+	if err == nil {
+		p.builder.NodeAt(parent).extent = p.Range(arrayStart[:len(arrayStart)-len(rest)])
+	}
 
 	return parent, rest, err
 }
@@ -772,6 +838,16 @@ func (p *Parser) parseMultilineBasicString(b []byte) ([]byte, []byte, []byte, er
 				builder.WriteByte('\t')
 			case 'e':
 				builder.WriteByte(0x1B)
+			case 'x':
+				if p.SpecVersion < TOML11 {
+					return nil, nil, nil, NewParserError(token[i:i+1], "invalid escaped character %#U", c)
+				}
+				x, err := hexToRune(atmost(token[i+1:], 2), 2)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				builder.WriteRune(x)
+				i += 2
 			case 'u':
 				x, err := hexToRune(atmost(token[i+1:], 4), 4)
 				if err != nil {
@@ -882,6 +958,7 @@ func (p *Parser) parseBasicString(b []byte) ([]byte, []byte, []byte, error) {
 	// escape-seq-char =/ %x6E         ; n    line feed       U+000A
 	// escape-seq-char =/ %x72         ; r    carriage return U+000D
 	// escape-seq-char =/ %x74         ; t    tab             U+0009
+	// escape-seq-char =/ %x78 2HEXDIG ; xHH                  U+00HH (TOML 1.1)
 	// escape-seq-char =/ %x75 4HEXDIG ; uXXXX                U+XXXX
 	// escape-seq-char =/ %x55 8HEXDIG ; UXXXXXXXX            U+XXXXXXXX
 	token, escaped, rest, err := scanBasicString(b)
@@ -931,6 +1008,17 @@ func (p *Parser) parseBasicString(b []byte) ([]byte, []byte, []byte, error) {
 				builder.WriteByte('\t')
 			case 'e':
 				builder.WriteByte(0x1B)
+			case 'x':
+				if p.SpecVersion < TOML11 {
+					return nil, nil, nil, NewParserError(token[i:i+1], "invalid escaped character %#U", c)
+				}
+				x, err := hexToRune(token[i+1:len(token)-1], 2)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+
+				builder.WriteRune(x)
+				i += 2
 			case 'u':
 				x, err := hexToRune(token[i+1:len(token)-1], 4)
 				if err != nil {
@@ -1115,6 +1203,7 @@ byteLoop:
 	return p.builder.Push(Node{
 		Kind: kind,
 		Data: b[:i],
+		Raw:  p.Range(b[:i]),
 	}), b[i:], nil
 }
 
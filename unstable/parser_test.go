@@ -448,6 +448,153 @@ func TestParser_AST_DateTimes(t *testing.T) {
 	}
 }
 
+func TestParser_SpecVersion(t *testing.T) {
+	examples := []struct {
+		desc  string
+		input string
+		v10   bool
+		v11   bool
+	}{
+		{
+			desc:  "inline table with new lines",
+			input: "a = {\n  b = 1,\n  c = 2\n}",
+			v10:   false,
+			v11:   true,
+		},
+		{
+			desc:  "inline table with comments",
+			input: "a = { # comment\n  b = 1, # comment\n}",
+			v10:   false,
+			v11:   true,
+		},
+		{
+			desc:  "inline table with trailing comma",
+			input: `a = { b = 1, }`,
+			v10:   false,
+			v11:   true,
+		},
+		{
+			desc:  "inline table with only a comma",
+			input: `a = { , }`,
+			v10:   false,
+			v11:   false,
+		},
+		{
+			desc:  "inline table with two trailing commas",
+			input: `a = { b = 1,, }`,
+			v10:   false,
+			v11:   false,
+		},
+		{
+			desc:  "hex escape",
+			input: `a = "\x41"`,
+			v10:   false,
+			v11:   true,
+		},
+		{
+			desc:  "hex escape in multiline string",
+			input: `a = """\x41"""`,
+			v10:   false,
+			v11:   true,
+		},
+		{
+			desc:  "short hex escape",
+			input: `a = "\x4"`,
+			v10:   false,
+			v11:   false,
+		},
+		{
+			desc:  "esc escape",
+			input: `a = "\e"`,
+			v10:   true,
+			v11:   true,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			for _, v := range []SpecVersion{TOML10, TOML11} {
+				p := Parser{SpecVersion: v}
+				p.Reset([]byte(e.input))
+				p.NextExpression()
+
+				valid := e.v10
+				if v == TOML11 {
+					valid = e.v11
+				}
+				if valid {
+					require.NoError(t, p.Error(), "version %d", v)
+				} else {
+					require.Error(t, p.Error(), "version %d", v)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_InlineTableNewlines(t *testing.T) {
+	p := Parser{SpecVersion: TOML11, KeepComments: true}
+	p.Reset([]byte("a = { # comment\n  b = 1,\n  c = 2,\n}"))
+	require.True(t, p.NextExpression())
+	require.NoError(t, p.Error())
+
+	expected := astNode{
+		Kind: KeyValue,
+		Children: []astNode{
+			{
+				Kind: InlineTable,
+				Children: []astNode{
+					{
+						Kind: KeyValue,
+						Children: []astNode{
+							{Kind: Integer, Data: []byte(`1`)},
+							{Kind: Key, Data: []byte(`b`)},
+						},
+					},
+					{
+						Kind: KeyValue,
+						Children: []astNode{
+							{Kind: Integer, Data: []byte(`2`)},
+							{Kind: Key, Data: []byte(`c`)},
+						},
+					},
+				},
+			},
+			{Kind: Key, Data: []byte(`a`)},
+		},
+	}
+	compareNode(t, expected, p.Expression())
+}
+
+func TestNodeExtent(t *testing.T) {
+	doc := "[a . b]\nc = [1, { d = [] }] # x\n[[e]]\nf = true\n"
+	p := Parser{}
+	p.Reset([]byte(doc))
+
+	var extents []string
+	var raws []string
+	for p.NextExpression() {
+		e := p.Expression()
+		if e.Kind == KeyValue {
+			e = e.Value()
+		}
+		extents = append(extents, string(p.Raw(e.Extent())))
+		raws = append(raws, string(p.Raw(e.Raw)))
+		if e.Kind == Array {
+			it := e.Children()
+			for it.Next() {
+				extents = append(extents, string(p.Raw(it.Node().Extent())))
+				raws = append(raws, string(p.Raw(it.Node().Raw)))
+			}
+		}
+	}
+	require.NoError(t, p.Error())
+
+	require.Equal(t, []string{"[a . b]", "[1, { d = [] }]", "1", "{ d = [] }", "[[e]]", "true"}, extents)
+	require.Equal(t, []string{"", "", "1", "{", "", "true"}, raws)
+}
+
 // This example demonstrates how to parse a TOML document and preserving
 // comments.  Comments are stored in the AST as Comment nodes. This example
 // displays the structure of the full AST generated by the parser using the
@@ -0,0 +1,194 @@
+package toml
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// variants are the concrete types stored in an interface type, identified by
+// the value of the discriminator key of the tables they are decoded from.
+type variants struct {
+	iface reflect.Type
+	key   string
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// newVariants validates the arguments of RegisterVariants. It panics if iface
+// is not a pointer to an interface type, or if a variant does not implement
+// it.
+func newVariants(iface interface{}, key string, types map[string]interface{}) *variants {
+	t := reflect.TypeOf(iface)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		panic(fmt.Errorf("toml: variants must be registered for a pointer to an interface type, not %v", t))
+	}
+
+	vs := &variants{
+		iface: t.Elem(),
+		key:   key,
+		types: make(map[string]reflect.Type, len(types)),
+		names: make(map[reflect.Type]string, len(types)),
+	}
+	for name, v := range types {
+		vt := reflect.TypeOf(v)
+		if vt == nil || !vt.Implements(vs.iface) {
+			panic(fmt.Errorf("toml: variant %q of type %v does not implement %s", name, vt, vs.iface))
+		}
+		vs.types[name] = vt
+		vs.names[vt] = name
+	}
+
+	return vs
+}
+
+// list returns the sorted names of the variants.
+func (vs *variants) list() string {
+	names := make([]string, 0, len(vs.types))
+	for name := range vs.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// RegisterVariants makes Decode choose the concrete type stored in the
+// interface type pointed to by iface according to the value of the key
+// discriminator of the table it is decoded from. types maps the values of the
+// discriminator to a value of the concrete type, for example:
+//
+//	d.RegisterVariants((*PluginConfig)(nil), "type", map[string]interface{}{
+//		"s3":   &S3Config{},
+//		"http": &HTTPConfig{},
+//	})
+//
+// This applies to fields, slice elements and map values of the interface type,
+// decoded from tables, array table elements, or inline tables. Pointer types
+// are decoded into a newly allocated value. The discriminator itself is not
+// decoded into the concrete type. A table without the discriminator, or with a
+// value not listed in types, results in a DecodeError.
+//
+// Use Encoder.RegisterVariants to write the discriminator when encoding.
+//
+// RegisterVariants panics if iface is not a pointer to an interface type, or if
+// a variant does not implement it.
+func (d *Decoder) RegisterVariants(iface interface{}, key string, types map[string]interface{}) *Decoder {
+	vs := newVariants(iface, key, types)
+	if d.variants == nil {
+		d.variants = map[reflect.Type]*variants{}
+	}
+	d.variants[vs.iface] = vs
+	return d
+}
+
+// RegisterVariants makes Encode write the key discriminator at the beginning
+// of the tables encoded from values of the interface type pointed to by iface.
+// Its value is the name of the concrete type of the value in types. It
+// mirrors Decoder.RegisterVariants, and accepts the same arguments.
+//
+// RegisterVariants panics if iface is not a pointer to an interface type, or if
+// a variant does not implement it.
+func (enc *Encoder) RegisterVariants(iface interface{}, key string, types map[string]interface{}) *Encoder {
+	vs := newVariants(iface, key, types)
+	if enc.variants == nil {
+		enc.variants = map[reflect.Type]*variants{}
+	}
+	enc.variants[vs.iface] = vs
+	return enc
+}
+
+// variantEntry returns the discriminator of the value held by the interface v,
+// if its concrete type is a registered variant.
+func (enc *Encoder) variantEntry(v reflect.Value) *entry {
+	vs := enc.variants[v.Type()]
+	if vs == nil {
+		return nil
+	}
+	name, ok := vs.names[v.Elem().Type()]
+	if !ok {
+		return nil
+	}
+	return &entry{Key: vs.key, Value: reflect.ValueOf(name)}
+}
+
+// tableVariants returns the variants of v, if v is an interface with
+// registered variants.
+func (d *decoder) tableVariants(v reflect.Value) *variants {
+	if v.Kind() != reflect.Interface {
+		return nil
+	}
+	return d.variants[v.Type()]
+}
+
+// unmarshalVariant decodes the inline table node into a new value of the
+// concrete type designated by its discriminator, and stores it in v.
+func (d *decoder) unmarshalVariant(vs *variants, node *unstable.Node, v reflect.Value) error {
+	if node.Kind != unstable.InlineTable {
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(node.Extent()), "%s can only be decoded from a table, not %s", vs.iface, node.Kind), CodeTypeMismatch, tomlKind(node.Kind), vs.iface)
+	}
+
+	var discriminator *unstable.Node
+	it := node.Children()
+	for it.Next() {
+		key := it.Node().Key()
+		if key.Next() && key.IsLast() && string(key.Node().Data) == vs.key {
+			discriminator = it.Node()
+			break
+		}
+	}
+	if discriminator == nil {
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(node.Raw)[:1], "missing key %s to select the type of %s", vs.key, vs.iface), CodeOther, "", vs.iface)
+	}
+
+	value := discriminator.Value()
+	if value.Kind != unstable.String {
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "key %s must be a string, not %s", vs.key, value.Kind), CodeTypeMismatch, tomlKind(value.Kind), stringType)
+	}
+	t, ok := vs.types[string(value.Data)]
+	if !ok {
+		return withErrorDetails(unstable.NewParserError(d.p.Raw(value.Raw), "unknown %s %q for %s, expected one of: %s", vs.key, value.Data, vs.iface, vs.list()), CodeOther, "", vs.iface)
+	}
+
+	var x, elem reflect.Value
+	if t.Kind() == reflect.Ptr {
+		x = reflect.New(t.Elem())
+		elem = x.Elem()
+	} else {
+		x = reflect.New(t).Elem()
+		elem = x
+	}
+
+	it = node.Children()
+	for it.Next() {
+		n := it.Node()
+		if n == discriminator {
+			continue
+		}
+
+		y, err := d.handleKeyValue(n, elem)
+		if err != nil {
+			return err
+		}
+		if y.IsValid() {
+			elem.Set(y)
+		}
+	}
+
+	// The fields of the variant are not known before the discriminator is
+	// read, so they are checked here rather than along with the document.
+	if typeHasFieldTags(t) {
+		keys := &documentKeys{}
+		keys.setValue(node)
+		err := d.checkFields(elem, keys, "", d.errorKey())
+		if err != nil {
+			return err
+		}
+	}
+
+	v.Set(x)
+
+	return nil
+}
@@ -0,0 +1,393 @@
+package toml_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pluginSettings interface {
+	Plugin() string
+}
+
+type s3Plugin struct {
+	Bucket  string
+	Options map[string]int `toml:"options,omitempty"`
+}
+
+func (s3Plugin) Plugin() string { return "s3" }
+
+type httpPlugin struct {
+	URL     string `toml:"url"`
+	Retries int    `toml:"retries,omitempty"`
+}
+
+func (*httpPlugin) Plugin() string { return "http" }
+
+var pluginVariants = map[string]interface{}{
+	"s3":   s3Plugin{},
+	"http": &httpPlugin{},
+}
+
+func TestDecoderVariants(t *testing.T) {
+	type config struct {
+		Main    pluginSettings
+		Plugins []pluginSettings
+		Named   map[string]pluginSettings
+	}
+
+	examples := []struct {
+		desc     string
+		input    string
+		expected config
+	}{
+		{
+			desc: "array tables",
+			input: `
+[[plugins]]
+type = "s3"
+bucket = "logs"
+[plugins.options]
+parts = 2
+
+[[plugins]]
+url = "http://localhost"
+type = "http"
+`,
+			expected: config{Plugins: []pluginSettings{
+				s3Plugin{Bucket: "logs", Options: map[string]int{"parts": 2}},
+				&httpPlugin{URL: "http://localhost"},
+			}},
+		},
+		{
+			desc: "tables",
+			input: `
+[main]
+type = "http"
+url = "http://main"
+retries = 3
+
+[named.a]
+type = "s3"
+bucket = "a"
+[named.b]
+type = "http"
+`,
+			expected: config{
+				Main: &httpPlugin{URL: "http://main", Retries: 3},
+				Named: map[string]pluginSettings{
+					"a": s3Plugin{Bucket: "a"},
+					"b": &httpPlugin{},
+				},
+			},
+		},
+		{
+			desc: "inline tables",
+			input: `
+main = { type = "s3", bucket = "main" }
+plugins = [{ type = "http", url = "http://a" }, { bucket = "b", type = "s3" }]
+named = { a = { type = "http", retries = 1 } }
+`,
+			expected: config{
+				Main: s3Plugin{Bucket: "main"},
+				Plugins: []pluginSettings{
+					&httpPlugin{URL: "http://a"},
+					s3Plugin{Bucket: "b"},
+				},
+				Named: map[string]pluginSettings{"a": &httpPlugin{Retries: 1}},
+			},
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			for _, streaming := range []bool{false, true} {
+				var c config
+				d := toml.NewDecoder(strings.NewReader(e.input)).
+					RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+					DisallowUnknownFields()
+				if streaming {
+					d.EnableStreaming()
+				}
+				err := d.Decode(&c)
+				require.NoError(t, err)
+				assert.Equal(t, e.expected, c)
+			}
+		})
+	}
+}
+
+func TestDecoderVariantsErrors(t *testing.T) {
+	type config struct {
+		Main    pluginSettings
+		Plugins []pluginSettings
+	}
+
+	examples := []struct {
+		desc     string
+		input    string
+		err      string
+		row, col int
+	}{
+		{
+			desc:  "missing discriminator",
+			input: "[[plugins]]\nbucket = 'a'\n",
+			err:   "toml: missing key type to select the type of toml_test.pluginSettings",
+			row:   1,
+			col:   1,
+		},
+		{
+			desc:  "unknown variant",
+			input: "[[plugins]]\ntype = \"s3\"\n\n[[plugins]]\n  type = \"ftp\"\n",
+			err:   `toml: unknown type "ftp" for toml_test.pluginSettings, expected one of: http, s3`,
+			row:   5,
+			col:   10,
+		},
+		{
+			desc:  "discriminator is not a string",
+			input: "main = { type = 1 }",
+			err:   "toml: key type must be a string, not Integer",
+			row:   1,
+			col:   17,
+		},
+		{
+			desc:  "not a table",
+			input: "main = 'a'",
+			err:   "toml: toml_test.pluginSettings can only be decoded from a table, not String",
+			row:   1,
+			col:   8,
+		},
+		{
+			desc:  "type mismatch",
+			input: "[main]\ntype = 'http'\nurl = 'u'\nretries = 'many'\n",
+			err:   "toml: cannot decode TOML string into struct field toml_test.httpPlugin.Retries of type int",
+			row:   4,
+			col:   11,
+		},
+	}
+
+	for _, e := range examples {
+		e := e
+		t.Run(e.desc, func(t *testing.T) {
+			var c config
+			err := toml.NewDecoder(strings.NewReader(e.input)).
+				RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+				Decode(&c)
+			var derr *toml.DecodeError
+			require.True(t, errors.As(err, &derr), "%v", err)
+			assert.Equal(t, e.err, derr.Error())
+			row, col := derr.Position()
+			assert.Equal(t, e.row, row)
+			assert.Equal(t, e.col, col)
+		})
+	}
+}
+
+func TestDecoderVariantsErrorsStreaming(t *testing.T) {
+	// The table is not available anymore once it is decoded, so errors are
+	// reported at its header.
+	var c struct{ Plugins []pluginSettings }
+	err := toml.NewDecoder(strings.NewReader("[[plugins]]\ntype = \"s3\"\n\n[[plugins]]\n  type = \"ftp\"\n")).
+		RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+		EnableStreaming().
+		Decode(&c)
+
+	var derr *toml.DecodeError
+	require.True(t, errors.As(err, &derr), "%v", err)
+	assert.Equal(t, `toml: unknown type "ftp" for toml_test.pluginSettings, expected one of: http, s3`, derr.Error())
+	assert.Equal(t, toml.Key{"plugins"}, derr.Key())
+	row, col := derr.Position()
+	assert.Equal(t, 4, row)
+	assert.Equal(t, 1, col)
+}
+
+func TestDecoderVariantsStrict(t *testing.T) {
+	doc := `
+[[plugins]]
+type = "s3"
+bucket = "a"
+region = "eu"
+
+[[plugins]]
+type = "http"
+url = "http://a"
+[plugins.tls]
+verify = true
+`
+
+	var c struct{ Plugins []pluginSettings }
+	err := toml.NewDecoder(strings.NewReader(doc)).
+		RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+		DisallowUnknownFields().
+		Decode(&c)
+
+	var serr *toml.StrictMissingError
+	require.True(t, errors.As(err, &serr), "%v", err)
+	require.Len(t, serr.Errors, 2)
+	assert.Equal(t, toml.Key{"plugins", "region"}, serr.Errors[0].Key())
+	row, _ := serr.Errors[0].Position()
+	assert.Equal(t, 5, row)
+	assert.Equal(t, toml.Key{"plugins", "tls", "verify"}, serr.Errors[1].Key())
+	row, _ = serr.Errors[1].Position()
+	assert.Equal(t, 10, row)
+}
+
+type ftpPlugin struct {
+	Host string `toml:"host,required"`
+	Port int    `toml:"port" default:"21"`
+	Mode string `toml:"mode" default:"'passive'"`
+}
+
+func (ftpPlugin) Plugin() string { return "ftp" }
+
+func TestDecoderVariantsOptions(t *testing.T) {
+	doc := `
+[main]
+type = "ftp"
+port = 'x'
+mode = "${MODE}"
+extra = 1
+
+[[plugins]]
+type = "ftp"
+host = "a"
+`
+
+	var c struct {
+		Main    pluginSettings
+		Plugins []pluginSettings
+	}
+	var md toml.Metadata
+	err := toml.NewDecoder(strings.NewReader(doc)).
+		RegisterVariants((*pluginSettings)(nil), "type", map[string]interface{}{"ftp": ftpPlugin{}}).
+		ExpandVariables(func(name string) (string, bool) { return "active", name == "MODE" }).
+		RecordMetadata(&md).
+		CollectErrors().
+		Decode(&c)
+
+	var derrs *toml.DecodeErrors
+	require.True(t, errors.As(err, &derrs), "%v", err)
+	require.Len(t, derrs.Errors, 1)
+	assert.Equal(t, toml.Key{"main", "port"}, derrs.Errors[0].Key())
+	row, col := derrs.Errors[0].Position()
+	assert.Equal(t, 4, row)
+	assert.Equal(t, 8, col)
+	require.NotNil(t, derrs.Required)
+	assert.Equal(t, []toml.MissingField{{Field: "Host", Key: toml.Key{"main", "host"}}}, derrs.Required.Fields)
+
+	assert.Equal(t, ftpPlugin{Mode: "active"}, c.Main)
+	assert.Equal(t, []pluginSettings{ftpPlugin{Host: "a", Port: 21, Mode: "passive"}}, c.Plugins)
+	assert.Equal(t, []toml.Key{{"main", "port"}, {"main", "extra"}}, md.Undecoded())
+}
+
+func TestDecoderVariantsPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		toml.NewDecoder(nil).RegisterVariants(pluginSettings(nil), "type", pluginVariants)
+	})
+	assert.Panics(t, func() {
+		toml.NewDecoder(nil).RegisterVariants((*pluginSettings)(nil), "type", map[string]interface{}{
+			"http": httpPlugin{},
+		})
+	})
+	assert.Panics(t, func() {
+		toml.NewEncoder(nil).RegisterVariants((*pluginSettings)(nil), "type", map[string]interface{}{
+			"none": nil,
+		})
+	})
+}
+
+func TestEncoderVariants(t *testing.T) {
+	type config struct {
+		Main    pluginSettings
+		Inline  pluginSettings `toml:",inline"`
+		Plugins []pluginSettings
+		Named   map[string]pluginSettings
+	}
+
+	c := config{
+		Main:   &httpPlugin{URL: "http://main"},
+		Inline: s3Plugin{Bucket: "inline"},
+		Plugins: []pluginSettings{
+			s3Plugin{Bucket: "logs", Options: map[string]int{"parts": 2}},
+			&httpPlugin{URL: "http://localhost", Retries: 1},
+		},
+		Named: map[string]pluginSettings{"a": s3Plugin{Bucket: "a"}},
+	}
+
+	var buf bytes.Buffer
+	err := toml.NewEncoder(&buf).
+		RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+		Encode(c)
+	require.NoError(t, err)
+
+	expected := `Inline = {type = 's3', Bucket = 'inline'}
+
+[Main]
+type = 'http'
+url = 'http://main'
+
+[[Plugins]]
+type = 's3'
+Bucket = 'logs'
+
+[Plugins.options]
+parts = 2
+
+[[Plugins]]
+type = 'http'
+url = 'http://localhost'
+retries = 1
+
+[Named]
+[Named.a]
+type = 's3'
+Bucket = 'a'
+`
+	assert.Equal(t, expected, buf.String())
+
+	var decoded config
+	err = toml.NewDecoder(&buf).
+		RegisterVariants((*pluginSettings)(nil), "type", pluginVariants).
+		Decode(&decoded)
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+}
+
+func ExampleDecoder_RegisterVariants() {
+	doc := `
+[[plugins]]
+type = "s3"
+bucket = "logs"
+
+[[plugins]]
+type = "http"
+url = "http://localhost:8080"
+`
+
+	var cfg struct {
+		Plugins []pluginSettings
+	}
+	err := toml.NewDecoder(strings.NewReader(doc)).
+		RegisterVariants((*pluginSettings)(nil), "type", map[string]interface{}{
+			"s3":   s3Plugin{},
+			"http": &httpPlugin{},
+		}).
+		Decode(&cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, p := range cfg.Plugins {
+		fmt.Printf("%s: %+v\n", p.Plugin(), p)
+	}
+	// Output:
+	// s3: {Bucket:logs Options:map[]}
+	// http: &{URL:http://localhost:8080 Retries:0}
+}